@@ -0,0 +1,67 @@
+// Command arena plays a match of computer-vs-computer games between two engines
+// registered in the application and prints the results.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"tictactoe/internal/di"
+	"tictactoe/internal/domain/engine"
+	"tictactoe/internal/domain/game"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+func main() {
+	first := flag.String("first", engine.MinimaxName, "name of the first engine")
+	second := flag.String("second", engine.RandomName, "name of the second engine")
+	cfg := engine.ArenaConfig{Rules: game.DefaultRules()}
+	flag.IntVar(&cfg.Games, "games", 100, "number of games, the engines alternate colours")
+	flag.IntVar(&cfg.Rules.Size, "size", game.GridSize, "number of rows and cols")
+	flag.IntVar(&cfg.Rules.WinLength, "win", game.GridSize, "number of same marks in a row needed to win")
+	flag.DurationVar(&cfg.MoveTime, "movetime", time.Second, "time budget of a move")
+	variantName := flag.String("variant", "", "rule set: classic, ultimate, gomoku, notakto, morris or qubic, the board of other variants is fixed")
+	boards := flag.Int("boards", game.NotaktoBoards, "number of boards of notakto games")
+	flag.BoolVar(&cfg.Rules.Fog, "fog", false, "classic games where each player sees only their own marks and the ones they hit")
+	flag.Parse()
+
+	variant, err := game.ParseVariant(*variantName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if variant != game.Classic {
+		cfg.Rules = variant.Rules()
+	}
+	if variant == game.Notakto {
+		cfg.Rules.Boards = *boards
+	}
+
+	var registry *engine.Registry
+	app := fx.New(di.FxConfig(), fx.Populate(&registry), fx.NopLogger)
+	if err := app.Err(); err != nil {
+		log.Fatalf("failed to create engines: %v", err)
+	}
+
+	firstStrategy, err := registry.Resolve(*first, cfg.Rules)
+	if err != nil {
+		log.Fatal(err)
+	}
+	secondStrategy, err := registry.Resolve(*second, cfg.Rules)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res, err := engine.PlayArena(context.Background(), firstStrategy, secondStrategy, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%s vs %s, %d %s games on %dx%d board\n", res.First, res.Second, res.Games, cfg.Rules.Variant, cfg.Rules.Size, cfg.Rules.Size)
+	fmt.Printf("wins: %d, draws: %d, losses: %d\n", res.Wins, res.Draws, res.Losses)
+	fmt.Printf("average game length: %.2f moves\n", res.AverageLength)
+	fmt.Printf("average move time: %s / %s\n", res.FirstMoveTime, res.SecondMoveTime)
+	fmt.Printf("total time: %s\n", res.Duration)
+}
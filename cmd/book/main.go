@@ -0,0 +1,32 @@
+// Command book builds an opening book from the games stored in datasource.GamesListFile
+// and writes it in the text format read by the server.
+package main
+
+import (
+	"flag"
+	"log"
+	"tictactoe/internal/datasource"
+	"tictactoe/internal/domain/game"
+)
+
+func main() {
+	depth := flag.Int("depth", 4, "number of first moves of a game added to the book")
+	out := flag.String("o", "internal/datasource/book.txt", "output file")
+	flag.Parse()
+
+	store := datasource.NewGameStore()
+	if err := store.LoadGamesFromJSON(); err != nil {
+		log.Fatalf("failed to load games: %v", err)
+	}
+
+	games, err := store.GetAllGames()
+	if err != nil {
+		log.Fatalf("failed to load games: %v", err)
+	}
+
+	book := game.BuildBook(games, *depth)
+	if err := datasource.SaveOpeningBook(*out, book); err != nil {
+		log.Fatalf("failed to write opening book: %v", err)
+	}
+	log.Printf("opening book of %d positions from %d games written to %s", book.Len(), len(games), *out)
+}
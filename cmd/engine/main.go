@@ -0,0 +1,41 @@
+// Command engine is the reference external engine. It speaks the line-based
+// protocol described in internal/domain/engine over stdin and stdout and
+// plays with the built-in minimax strategy.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"tictactoe/internal/config"
+	"tictactoe/internal/domain/engine"
+)
+
+func main() {
+	strategy, err := engine.NewMinimaxStrategy(&config.Config{})
+	if err != nil {
+		log.Fatalf("failed to create engine: %v", err)
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for scanner.Scan() {
+		g, currentPlayer, moveTime, err := engine.ParsePosition(scanner.Text())
+		if err != nil {
+			fmt.Printf("error %v\n", err)
+			continue
+		}
+
+		// keep a tenth of the budget for the reply to reach the server in time
+		ctx, cancel := context.WithTimeout(context.Background(), moveTime*9/10)
+		res, err := strategy.NextMove(ctx, g, currentPlayer)
+		cancel()
+		if err != nil {
+			fmt.Printf("error %v\n", err)
+			continue
+		}
+
+		fmt.Println(engine.FormatMove(res.Coord))
+	}
+}
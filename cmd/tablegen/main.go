@@ -0,0 +1,20 @@
+// Command tablegen solves all reachable positions of the standard board and
+// writes the perfect-play tablebase embedded by the game package.
+// It is run by go generate in internal/domain/game.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"tictactoe/internal/domain/game"
+)
+
+func main() {
+	out := flag.String("o", "tablebase.bin", "output file")
+	flag.Parse()
+
+	if err := os.WriteFile(*out, game.BuildTablebase(), 0644); err != nil {
+		log.Fatalf("failed to write tablebase: %v", err)
+	}
+}
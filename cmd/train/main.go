@@ -0,0 +1,32 @@
+// Command train learns a policy for the qlearning engine by tabular Q-learning
+// in self-play games on the standard board and writes it to a file.
+// Training with the same flags produces the same policy.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"tictactoe/internal/domain/engine"
+)
+
+func main() {
+	cfg := engine.TrainConfig{}
+	flag.IntVar(&cfg.Episodes, "episodes", 100000, "number of self-play games")
+	flag.Int64Var(&cfg.Seed, "seed", 1, "seed of the exploration")
+	flag.Float64Var(&cfg.Alpha, "alpha", 0.3, "learning rate")
+	flag.Float64Var(&cfg.Gamma, "gamma", 0.95, "discount of values of later positions")
+	flag.Float64Var(&cfg.Epsilon, "epsilon", 0.3, "probability of an exploring random move")
+	out := flag.String("o", "internal/datasource/policy.json", "output file")
+	flag.Parse()
+
+	data, err := engine.MarshalPolicy(engine.TrainPolicy(cfg))
+	if err != nil {
+		log.Fatalf("failed to encode policy: %v", err)
+	}
+
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		log.Fatalf("failed to write policy: %v", err)
+	}
+	log.Printf("policy of %d episodes written to %s", cfg.Episodes, *out)
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the application settings.
+// Every setting has a default value that can be overridden by an environment variable.
+type Config struct {
+	MoveTime       time.Duration // TICTACTOE_MOVE_TIME: time budget of a computer move
+	SearchWorkers  int           // TICTACTOE_SEARCH_WORKERS: goroutines of a parallel search, 0 for GOMAXPROCS
+	MCTSIterations int           // TICTACTOE_MCTS_ITERATIONS: playouts per computer move, 0 for no limit
+	MCTSTimeLimit  time.Duration // TICTACTOE_MCTS_TIME: search time per computer move within MoveTime, 0 for no limit
+	MCTSSeed       int64         // TICTACTOE_MCTS_SEED: seed of the playouts, 0 to seed from the clock
+	MinimaxSeed    int64         // TICTACTOE_MINIMAX_SEED: seed of the choice among equally good moves, 0 to seed from the clock
+
+	PolicyFile string // TICTACTOE_POLICY_FILE: policy of the qlearning engine written by cmd/train
+	BookFile   string // TICTACTOE_BOOK_FILE: opening book consulted before search, written by cmd/book
+
+	// TICTACTOE_EXTERNAL_ENGINES: external engine executables by engine name, "name=path,name=path"
+	ExternalEngines map[string]string
+	ExternalTimeout time.Duration // TICTACTOE_EXTERNAL_TIMEOUT: maximum wait for an external engine reply
+}
+
+// NewConfig creates Config with default values overridden by environment variables.
+// Returns an error if a variable can't be parsed
+func NewConfig() (*Config, error) {
+	cfg := Config{
+		MoveTime:        2 * time.Second,
+		MCTSIterations:  20000,
+		PolicyFile:      "internal/datasource/policy.json",
+		BookFile:        "internal/datasource/book.txt",
+		ExternalEngines: map[string]string{},
+		ExternalTimeout: 5 * time.Second,
+	}
+
+	if err := lookupDuration("TICTACTOE_MOVE_TIME", &cfg.MoveTime); err != nil {
+		return nil, err
+	}
+	if err := lookupInt("TICTACTOE_SEARCH_WORKERS", &cfg.SearchWorkers); err != nil {
+		return nil, err
+	}
+	if err := lookupInt("TICTACTOE_MCTS_ITERATIONS", &cfg.MCTSIterations); err != nil {
+		return nil, err
+	}
+	if err := lookupDuration("TICTACTOE_MCTS_TIME", &cfg.MCTSTimeLimit); err != nil {
+		return nil, err
+	}
+	if err := lookupInt64("TICTACTOE_MCTS_SEED", &cfg.MCTSSeed); err != nil {
+		return nil, err
+	}
+	if err := lookupInt64("TICTACTOE_MINIMAX_SEED", &cfg.MinimaxSeed); err != nil {
+		return nil, err
+	}
+
+	if value, ok := os.LookupEnv("TICTACTOE_POLICY_FILE"); ok {
+		cfg.PolicyFile = value
+	}
+	if value, ok := os.LookupEnv("TICTACTOE_BOOK_FILE"); ok {
+		cfg.BookFile = value
+	}
+	if err := lookupEngines("TICTACTOE_EXTERNAL_ENGINES", cfg.ExternalEngines); err != nil {
+		return nil, err
+	}
+	if err := lookupDuration("TICTACTOE_EXTERNAL_TIMEOUT", &cfg.ExternalTimeout); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// lookupInt sets value from the environment variable if it is present
+func lookupInt(name string, value *int) error {
+	str, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(str)
+	if err != nil || parsed < 0 {
+		return fmt.Errorf("invalid %s: %q", name, str)
+	}
+
+	*value = parsed
+	return nil
+}
+
+// lookupInt64 sets value from the environment variable if it is present
+func lookupInt64(name string, value *int64) error {
+	str, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %q", name, str)
+	}
+
+	*value = parsed
+	return nil
+}
+
+// lookupDuration sets value from the environment variable if it is present.
+// The value is parsed by time.ParseDuration, e.g. "500ms" or "2s"
+func lookupDuration(name string, value *time.Duration) error {
+	str, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(str)
+	if err != nil || parsed < 0 {
+		return fmt.Errorf("invalid %s: %q", name, str)
+	}
+
+	*value = parsed
+	return nil
+}
+
+// lookupEngines adds engines from the environment variable in "name=path,name=path" format if it is present
+func lookupEngines(name string, engines map[string]string) error {
+	str, ok := os.LookupEnv(name)
+	if !ok || str == "" {
+		return nil
+	}
+
+	for _, pair := range strings.Split(str, ",") {
+		engine, path, ok := strings.Cut(pair, "=")
+		if !ok || engine == "" || path == "" {
+			return fmt.Errorf("invalid %s: %q", name, str)
+		}
+		engines[engine] = path
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package datasource
+
+import (
+	"fmt"
+	"os"
+	"tictactoe/internal/domain/game"
+)
+
+// LoadOpeningBook reads the opening book from the text file at path.
+// Returns an error if the file can't be read or parsed
+func LoadOpeningBook(path string) (*game.Book, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer file.Close()
+
+	book, err := game.ParseBook(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse opening book: %w", err)
+	}
+	return book, nil
+}
+
+// SaveOpeningBook writes the opening book to the text file at path.
+// Returns an error if file writing fails
+func SaveOpeningBook(path string, book *game.Book) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := book.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -1,6 +1,7 @@
 package datasource
 
 import (
+	"fmt"
 	"tictactoe/internal/domain/game"
 
 	"github.com/google/uuid"
@@ -9,10 +10,26 @@ import (
 // GameDTO is a Data Transfer Object for serializing and deserializing game.Game.
 // It is used to convert the internal game state to a JSON-compatible format.
 type GameDTO struct {
-	ID     string                            `json:"gameID"`
-	State  int                               `json:"state"`
-	Grid   [game.GridSize][game.GridSize]int `json:"grid"`
-	Winner int                               `json:"winner"`
+	ID        string   `json:"gameID"`
+	State     int      `json:"state"`
+	Grid      [][]int  `json:"grid"`
+	Winner    int      `json:"winner"`
+	WinLength int      `json:"winLength,omitempty"`
+	Engine    string   `json:"engine,omitempty"`
+	Variant   string   `json:"variant,omitempty"`
+	ExactFive bool     `json:"exactFive,omitempty"`
+	Misere    bool     `json:"misere,omitempty"`
+	Gravity   bool     `json:"gravity,omitempty"`
+	Wild      bool     `json:"wild,omitempty"`
+	Torus     bool     `json:"torus,omitempty"`
+	Fog       bool     `json:"fog,omitempty"`
+	Moves     [][2]int `json:"moves,omitempty"`
+
+	Boards     [][][]int `json:"boards,omitempty"`     // Boards of notakto games, layers of qubic games
+	BoardMoves [][3]int  `json:"boardMoves,omitempty"` // Moves of multi-board games as board, row and col
+	Steps      [][4]int  `json:"steps,omitempty"`      // Moves of morris games as from row and col, -1 for placements, and to row and col
+	Start      [][]int   `json:"start,omitempty"`      // Starting layout of custom games, 3 for blocked cells
+	Hits       [][3]int  `json:"hits,omitempty"`       // Hits of fog games as player, row and col
 }
 
 // GameToDTO creates GameDTO struct from game.Game
@@ -21,33 +38,141 @@ func GameToDTO(g *game.Game) *GameDTO {
 	dto.ID = g.ID.String()
 	dto.State = int(g.State)
 	dto.Winner = int(g.Winner)
+	dto.WinLength = g.Rules.WinLength
+	dto.Engine = g.Engine
+	dto.ExactFive = g.Rules.ExactFive
+	dto.Misere = g.Rules.Misere
+	dto.Gravity = g.Rules.Gravity
+	dto.Wild = g.Rules.Wild
+	dto.Torus = g.Rules.Torus
+	dto.Fog = g.Rules.Fog
+	if g.Rules.Variant != game.Classic {
+		dto.Variant = g.Rules.Variant.String()
+	}
 
-	for i := 0; i < game.GridSize; i++ {
-		for j := 0; j < game.GridSize; j++ {
-			dto.Grid[i][j] = int(g.Grid[i][j])
-		}
+	for _, move := range g.Moves {
+		dto.Moves = append(dto.Moves, [2]int{move.Row, move.Col})
+	}
+
+	for _, move := range g.BoardMoves {
+		dto.BoardMoves = append(dto.BoardMoves, [3]int{move.Board, move.Row, move.Col})
+	}
+
+	for _, step := range g.Steps {
+		dto.Steps = append(dto.Steps, [4]int{step.From.Row, step.From.Col, step.To.Row, step.To.Col})
+	}
+
+	for _, hit := range g.Hits {
+		dto.Hits = append(dto.Hits, [3]int{int(hit.Player), hit.Cell.Row, hit.Cell.Col})
+	}
+
+	dto.Grid = gridToDTO(g.Grid)
+	if g.Rules.Custom {
+		dto.Start = gridToDTO(g.Start)
+	}
+	for _, board := range g.Boards {
+		dto.Boards = append(dto.Boards, gridToDTO(board))
 	}
 
 	return &dto
 }
 
-// GameFromDTO creates game.Game struct from GameDTO
+// GameFromDTO creates game.Game struct from GameDTO.
+// Games saved before board options were introduced get the standard rules
 func GameFromDTO(dto *GameDTO) (*game.Game, error) {
 	id, err := uuid.Parse(dto.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	variant, err := game.ParseVariant(dto.Variant)
+	if err != nil {
+		return nil, err
+	}
+
 	g := game.Game{}
 	g.ID = id
 	g.State = game.State(dto.State)
 	g.Winner = game.Mark(dto.Winner)
+	g.Rules = game.Rules{Size: len(dto.Grid), WinLength: dto.WinLength, Variant: variant, ExactFive: dto.ExactFive, Misere: dto.Misere, Gravity: dto.Gravity, Wild: dto.Wild, Torus: dto.Torus, Custom: dto.Start != nil, Fog: dto.Fog}
+	g.Engine = dto.Engine
+
+	if g.Rules.WinLength == 0 {
+		g.Rules.WinLength = game.GridSize
+	}
+	switch variant {
+	case game.Notakto:
+		g.Rules.Size = game.GridSize
+		g.Rules.Boards = len(dto.Boards)
+	case game.Qubic:
+		g.Rules.Size = len(dto.Boards)
+	}
+	if err := g.Rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, move := range dto.Moves {
+		g.Moves = append(g.Moves, game.Coord{Row: move[0], Col: move[1]})
+	}
+
+	for _, move := range dto.BoardMoves {
+		g.BoardMoves = append(g.BoardMoves, game.BoardCoord{Board: move[0], Row: move[1], Col: move[2]})
+	}
 
-	for i := 0; i < game.GridSize; i++ {
-		for j := 0; j < game.GridSize; j++ {
-			g.Grid[i][j] = game.Mark(dto.Grid[i][j])
+	for _, step := range dto.Steps {
+		g.Steps = append(g.Steps, game.Step{From: game.Coord{Row: step[0], Col: step[1]}, To: game.Coord{Row: step[2], Col: step[3]}})
+	}
+
+	for _, hit := range dto.Hits {
+		g.Hits = append(g.Hits, game.Hit{Player: game.Mark(hit[0]), Cell: game.Coord{Row: hit[1], Col: hit[2]}})
+	}
+
+	if g.Grid, err = gridFromDTO(dto.Grid, len(dto.Grid)); err != nil {
+		return nil, err
+	}
+	if g.Rules.Custom {
+		if g.Start, err = gridFromDTO(dto.Start, g.Rules.Size); err != nil {
+			return nil, err
+		}
+	}
+	for _, board := range dto.Boards {
+		grid, err := gridFromDTO(board, g.Rules.Size)
+		if err != nil {
+			return nil, err
 		}
+		g.Boards = append(g.Boards, grid)
 	}
 
 	return &g, nil
 }
+
+// gridToDTO converts a game.Grid into rows of mark numbers
+func gridToDTO(grid game.Grid) [][]int {
+	rows := make([][]int, len(grid))
+	for i := range grid {
+		rows[i] = make([]int, len(grid[i]))
+		for j := range grid[i] {
+			rows[i][j] = int(grid[i][j])
+		}
+	}
+	return rows
+}
+
+// gridFromDTO converts rows of mark numbers into a game.Grid with size rows and cols.
+// Returns an error if the rows don't make such a grid
+func gridFromDTO(rows [][]int, size int) (game.Grid, error) {
+	if len(rows) != size {
+		return nil, fmt.Errorf("grid is not square")
+	}
+
+	grid := game.NewGrid(size)
+	for i := range rows {
+		if len(rows[i]) != size {
+			return nil, fmt.Errorf("grid is not square")
+		}
+		for j := range rows[i] {
+			grid[i][j] = game.Mark(rows[i][j])
+		}
+	}
+	return grid, nil
+}
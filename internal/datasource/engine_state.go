@@ -0,0 +1,31 @@
+package datasource
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// EngineStateDir is the directory of the files that store learned states of engines, next to GamesListFile.
+var EngineStateDir = filepath.Dir(GamesListFile)
+
+// EngineStateFile returns the path to the file that stores the learned state of the named engine
+func EngineStateFile(name string) string {
+	return filepath.Join(EngineStateDir, fmt.Sprintf("engine_%s.json", name))
+}
+
+// LoadEngineState reads the learned state of the named engine.
+// Returns an error if the file can't be read
+func LoadEngineState(name string) ([]byte, error) {
+	data, err := os.ReadFile(EngineStateFile(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// SaveEngineState writes the learned state of the named engine.
+// Returns an error if file writing fails
+func SaveEngineState(name string, data []byte) error {
+	return os.WriteFile(EngineStateFile(name), data, 0644)
+}
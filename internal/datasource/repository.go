@@ -12,4 +12,6 @@ type GameRepository interface {
 	GetGame(id uuid.UUID) (*game.Game, error)
 	SaveGames() error
 	GetAllGames() ([]*game.Game, error)
+	LoadEngineState(name string) ([]byte, error)
+	SaveEngineState(name string, data []byte) error
 }
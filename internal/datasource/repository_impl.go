@@ -44,3 +44,13 @@ func (r *gameRepository) LoadGames() error {
 func (r *gameRepository) SaveGames() error {
 	return r.Storage.SaveGamesToJSON()
 }
+
+// LoadEngineState is loading the learned state of the named engine from json
+func (r *gameRepository) LoadEngineState(name string) ([]byte, error) {
+	return LoadEngineState(name)
+}
+
+// SaveEngineState is saving the learned state of the named engine to json
+func (r *gameRepository) SaveEngineState(name string, data []byte) error {
+	return SaveEngineState(name, data)
+}
@@ -3,16 +3,30 @@ package di
 import (
 	"go.uber.org/fx"
 
+	"tictactoe/internal/config"
 	"tictactoe/internal/datasource"
+	"tictactoe/internal/domain/engine"
 	"tictactoe/internal/domain/service"
 	"tictactoe/internal/web"
 )
 
 // FxConfig defines and provides all the application dependencies using fx.Provide.
-// It wires up the GameStore, GameRepository, GameService, GameHandler, and Gin router.
+// It wires up the Config, engine strategies and their Registry, GameStore, GameRepository,
+// GameService, GameHandler, and Gin router.
 // This configuration is used to construct the application's dependency graph.
 func FxConfig() fx.Option {
 	opt := fx.Provide(
+		config.NewConfig,
+		asStrategy(engine.NewMinimaxStrategy),
+		asStrategy(engine.NewRandomStrategy),
+		asStrategy(engine.NewHeuristicStrategy),
+		asStrategy(engine.NewThreatsStrategy),
+		asStrategy(engine.NewMCTSStrategy),
+		asStrategy(engine.NewMenaceStrategy),
+		asStrategy(engine.NewQLearningStrategy),
+		asStrategy(engine.NewFogStrategy),
+		fx.Annotate(engine.NewExternalStrategies, fx.ResultTags(`group:"strategies,flatten"`)),
+		fx.Annotate(engine.NewRegistry, fx.ParamTags(`group:"strategies"`)),
 		datasource.NewGameStore,
 		datasource.NewGameRepository,
 		service.NewGameService,
@@ -21,3 +35,9 @@ func FxConfig() fx.Option {
 	)
 	return opt
 }
+
+// asStrategy annotates a strategy constructor so that its result is collected
+// into the group of strategies passed to engine.NewRegistry
+func asStrategy(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"strategies"`))
+}
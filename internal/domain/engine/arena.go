@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"tictactoe/internal/domain/game"
+	"time"
+)
+
+// ArenaConfig holds the settings of a match between two strategies
+type ArenaConfig struct {
+	Games    int           // Number of games, the first strategy plays Cross in even games
+	Rules    game.Rules    // Rules of every game
+	MoveTime time.Duration // Time budget of every move
+}
+
+// ArenaResult holds the results of a match from the first strategy point of view
+type ArenaResult struct {
+	First          string        // Name of the first strategy
+	Second         string        // Name of the second strategy
+	Games          int           // Number of played games
+	Wins           int           // Games won by the first strategy
+	Draws          int           // Drawn games
+	Losses         int           // Games won by the second strategy
+	AverageLength  float64       // Average number of moves in a game
+	FirstMoveTime  time.Duration // Average time of a move of the first strategy
+	SecondMoveTime time.Duration // Average time of a move of the second strategy
+	Duration       time.Duration // Total time of the match
+}
+
+// PlayArena plays a match of computer-vs-computer games between two strategies alternating colours.
+// Returns an error if a strategy can't play the rules or fails to move, or if ctx is done
+func PlayArena(ctx context.Context, first, second Strategy, cfg ArenaConfig) (ArenaResult, error) {
+	res := ArenaResult{First: first.Name(), Second: second.Name()}
+	if err := cfg.Rules.Validate(); err != nil {
+		return res, err
+	}
+	for _, s := range []Strategy{first, second} {
+		if !s.Supports(cfg.Rules) {
+			return res, fmt.Errorf("engine %q does not support this board", s.Name())
+		}
+	}
+
+	start := time.Now()
+	totalMoves := 0
+	var moveTimes [2]time.Duration
+	var moveCounts [2]int
+
+	for i := 0; i < cfg.Games; i++ {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+
+		players := [2]Strategy{first, second}
+		if i%2 == 1 {
+			players = [2]Strategy{second, first}
+		}
+
+		g, err := game.NewGameWithRules(cfg.Rules)
+		if err != nil {
+			return res, err
+		}
+
+		currentPlayer := game.Cross
+		for turn := 0; ; turn++ {
+			if gameOver, _ := g.IsOver(); gameOver {
+				break
+			}
+
+			side := turn % 2
+			moveStart := time.Now()
+			moveCtx, cancel := context.WithTimeout(ctx, cfg.MoveTime)
+			move, err := players[side].NextMove(moveCtx, g, currentPlayer)
+			cancel()
+			if err != nil {
+				return res, fmt.Errorf("engine %q failed: %w", players[side].Name(), err)
+			}
+
+			// side 0 plays Cross, it is the first strategy in even games
+			index := (side + i) % 2
+			moveTimes[index] += time.Since(moveStart)
+			moveCounts[index]++
+
+			move.Apply(g, currentPlayer)
+			currentPlayer = game.GetOpponent(currentPlayer)
+		}
+
+		_, winner := g.IsOver()
+		firstMark := game.Cross
+		if i%2 == 1 {
+			firstMark = game.Nought
+		}
+
+		switch winner {
+		case game.Empty:
+			res.Draws++
+		case firstMark:
+			res.Wins++
+		default:
+			res.Losses++
+		}
+		res.Games++
+		totalMoves += g.MoveCount()
+	}
+
+	res.Duration = time.Since(start)
+	if res.Games > 0 {
+		res.AverageLength = float64(totalMoves) / float64(res.Games)
+	}
+	if moveCounts[0] > 0 {
+		res.FirstMoveTime = moveTimes[0] / time.Duration(moveCounts[0])
+	}
+	if moveCounts[1] > 0 {
+		res.SecondMoveTime = moveTimes[1] / time.Duration(moveCounts[1])
+	}
+	return res, nil
+}
@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"tictactoe/internal/domain/game"
+)
+
+// Strategy chooses the computer move in a game
+type Strategy interface {
+	// Name returns the unique name the strategy is selected by
+	Name() string
+	// Description returns a short human-readable description of the strategy
+	Description() string
+	// Supports reports whether the strategy can play a game with the given rules
+	Supports(rules game.Rules) bool
+	// NextMove returns the move for the current player, or an error if there is none.
+	// Searching strategies return the best move found so far when ctx is done
+	NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error)
+}
+
+// Result is the move chosen by a strategy
+type Result struct {
+	Coord game.Coord // Chosen cell
+	Depth int        // Depth in plies of the completed search, 0 for strategies that don't search
+	Mark  game.Mark  // Mark chosen for the cell in wild games, Empty for the player's own mark
+	Board int        // Board of the chosen cell in notakto games, layer in qubic games
+	From  game.Coord // Cell of the moved mark in morris games, NoCoord for placements
+}
+
+// PlacedMark returns the mark the move places for the current player
+func (r Result) PlacedMark(currentPlayer game.Mark) game.Mark {
+	if r.Mark != game.Empty {
+		return r.Mark
+	}
+	return currentPlayer
+}
+
+// Apply plays the move for the current player: the placed mark in the chosen cell,
+// a cross in the chosen cell of the board in notakto games, the player's mark in the chosen cell
+// of the layer in qubic games or the step in morris games
+func (r Result) Apply(g *game.Game, currentPlayer game.Mark) {
+	switch g.Rules.Variant {
+	case game.Notakto:
+		g.PlayBoard(game.BoardCoord{Board: r.Board, Row: r.Coord.Row, Col: r.Coord.Col}, game.Cross)
+	case game.Qubic:
+		g.PlayBoard(game.BoardCoord{Board: r.Board, Row: r.Coord.Row, Col: r.Coord.Col}, currentPlayer)
+	case game.Morris:
+		g.PlayStep(game.Step{From: r.From, To: r.Coord}, currentPlayer)
+	default:
+		g.Play(r.Coord, r.PlacedMark(currentPlayer))
+	}
+}
+
+// Registry holds the named strategies available to games
+type Registry struct {
+	strategies map[string]Strategy
+}
+
+// NewRegistry creates a Registry with the given strategies.
+// Returns an error if two strategies have the same name
+func NewRegistry(strategies []Strategy) (*Registry, error) {
+	r := Registry{strategies: map[string]Strategy{}}
+	for _, s := range strategies {
+		if _, ok := r.strategies[s.Name()]; ok {
+			return nil, fmt.Errorf("duplicate engine %q", s.Name())
+		}
+		r.strategies[s.Name()] = s
+	}
+	return &r, nil
+}
+
+// Get returns the strategy registered under the name
+func (r *Registry) Get(name string) (Strategy, error) {
+	s, ok := r.strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown engine %q", name)
+	}
+	return s, nil
+}
+
+// Resolve returns the strategy with the given name that can play the rules.
+// An empty name selects the default strategy for the rules
+func (r *Registry) Resolve(name string, rules game.Rules) (Strategy, error) {
+	if name == "" {
+		name = DefaultName(rules)
+	}
+
+	s, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.Supports(rules) {
+		return nil, fmt.Errorf("engine %q does not support this board", name)
+	}
+	return s, nil
+}
+
+// List returns all registered strategies sorted by name
+func (r *Registry) List() []Strategy {
+	list := make([]Strategy, 0, len(r.strategies))
+	for _, s := range r.strategies {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
+
+// DefaultName returns the name of the strategy used when a game doesn't choose one:
+// the fog strategy for fog games, minimax for the 3x3 board, wild, notakto and morris games,
+// the threat-based strategy for gomoku and qubic and MCTS for larger boards and other variants
+func DefaultName(rules game.Rules) string {
+	if rules.Fog {
+		return FogName
+	}
+	if (rules.Variant == game.Classic && rules.Size == game.GridSize) || rules.Wild || rules.Variant == game.Notakto || rules.Variant == game.Morris {
+		return MinimaxName
+	}
+	if rules.Variant == game.Gomoku || rules.Variant == game.Qubic {
+		return ThreatsName
+	}
+	return MCTSName
+}
+
+// resultOrError converts NoCoord returned by a search into an error
+func resultOrError(coord game.Coord, depth int) (Result, error) {
+	if coord == game.NoCoord {
+		return Result{Coord: game.NoCoord}, fmt.Errorf("could not find a valid move")
+	}
+	return Result{Coord: coord, Depth: depth}, nil
+}
@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"sync"
+	"tictactoe/internal/config"
+	"tictactoe/internal/domain/game"
+	"time"
+)
+
+type externalStrategy struct {
+	name    string
+	path    string
+	timeout time.Duration
+
+	mu    sync.Mutex
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string
+}
+
+// NewExternalStrategies creates a strategy for every external engine executable set in Config.
+// An engine process is started on the first move and restarted after a failure or a timeout
+func NewExternalStrategies(cfg *config.Config) []Strategy {
+	names := make([]string, 0, len(cfg.ExternalEngines))
+	for name := range cfg.ExternalEngines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	strategies := make([]Strategy, 0, len(names))
+	for _, name := range names {
+		strategies = append(strategies, &externalStrategy{
+			name:    name,
+			path:    cfg.ExternalEngines[name],
+			timeout: cfg.ExternalTimeout,
+		})
+	}
+	return strategies
+}
+
+// Name returns the name of the strategy
+func (s *externalStrategy) Name() string {
+	return s.name
+}
+
+// Description returns a short description of the strategy
+func (s *externalStrategy) Description() string {
+	return fmt.Sprintf("external engine %s", s.path)
+}
+
+// Supports reports that any board of the classic rules can be offered to the engine,
+// the protocol can't describe other variants, blocked cells and the misere, gravity, wild, torus and fog rules
+func (s *externalStrategy) Supports(rules game.Rules) bool {
+	return rules.Variant == game.Classic && !rules.Misere && !rules.Gravity && !rules.Wild && !rules.Torus && !rules.Custom && !rules.Fog
+}
+
+// NextMove sends the position to the engine process and waits for its move
+// until the timeout expires or ctx is done. The move is checked to be an empty cell.
+// The process is stopped after any failure, so the next move starts a new one
+func (s *externalStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.start(); err != nil {
+		return Result{Coord: game.NoCoord}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	moveTime := s.timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		moveTime = time.Until(deadline)
+	}
+
+	if _, err := fmt.Fprintln(s.stdin, FormatPosition(g, currentPlayer, moveTime)); err != nil {
+		s.stop()
+		return Result{Coord: game.NoCoord}, fmt.Errorf("failed to write to engine %s: %w", s.name, err)
+	}
+
+	select {
+	case line, ok := <-s.lines:
+		if !ok {
+			s.stop()
+			return Result{Coord: game.NoCoord}, fmt.Errorf("engine %s exited", s.name)
+		}
+
+		// a process that broke the protocol may have more lines to send, which would be read
+		// as the replies to later positions, so it is replaced like one that timed out
+		coord, err := ParseMove(line)
+		if err != nil {
+			s.stop()
+			return Result{Coord: game.NoCoord}, err
+		}
+		if coord.Row < 0 || coord.Row >= len(g.Grid) || coord.Col < 0 || coord.Col >= len(g.Grid) ||
+			g.Grid[coord.Row][coord.Col] != game.Empty {
+			s.stop()
+			return Result{Coord: game.NoCoord}, fmt.Errorf("engine %s made an illegal move", s.name)
+		}
+		return Result{Coord: coord}, nil
+	case <-ctx.Done():
+		s.stop()
+		return Result{Coord: game.NoCoord}, fmt.Errorf("engine %s timed out", s.name)
+	}
+}
+
+// start launches the engine process if it is not running
+func (s *externalStrategy) start() error {
+	if s.cmd != nil {
+		return nil
+	}
+
+	cmd := exec.Command(s.path)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start engine %s: %w", s.name, err)
+	}
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	s.cmd, s.stdin, s.lines = cmd, stdin, lines
+	return nil
+}
+
+// stop kills the engine process so that the next move starts a new one
+func (s *externalStrategy) stop() {
+	if s.cmd == nil {
+		return
+	}
+
+	s.stdin.Close()
+	s.cmd.Process.Kill()
+	go func(lines chan string) {
+		for range lines {
+		}
+	}(s.lines)
+	s.cmd.Wait()
+
+	s.cmd, s.stdin, s.lines = nil, nil, nil
+}
@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"tictactoe/internal/config"
+	"tictactoe/internal/domain/game"
+	"time"
+)
+
+// fakeEngineEnv makes the test binary run as a fake external engine, see fakeEngine
+const fakeEngineEnv = "TICTACTOE_FAKE_ENGINE"
+
+func TestMain(m *testing.M) {
+	if marker := os.Getenv(fakeEngineEnv); marker != "" {
+		fakeEngine(marker)
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// fakeEngine replies to every position with the first empty cell. The first process, the one that
+// creates the marker file, breaks the protocol: it sends a bad line before every move
+func fakeEngine(marker string) {
+	_, err := os.Stat(marker)
+	broken := os.IsNotExist(err)
+	if broken {
+		os.WriteFile(marker, nil, 0644)
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		g, _, _, err := ParsePosition(scanner.Text())
+		if err != nil {
+			fmt.Printf("error %v\n", err)
+			continue
+		}
+		if broken {
+			fmt.Println("bogus")
+		}
+		fmt.Println(FormatMove(g.EmptyCells()[0]))
+	}
+}
+
+func TestExternalStrategyRestartsAfterBadLine(t *testing.T) {
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(fakeEngineEnv, filepath.Join(t.TempDir(), "started"))
+
+	s := NewExternalStrategies(&config.Config{
+		ExternalEngines: map[string]string{"fake": executable},
+		ExternalTimeout: 5 * time.Second,
+	})[0]
+	defer s.(*externalStrategy).stop()
+
+	g := game.NewGame()
+	if _, err := s.NextMove(context.Background(), g, game.Cross); err == nil {
+		t.Fatal("bad line accepted as a move")
+	}
+
+	// the move after the bad line must not be taken as the reply to the next position
+	g.Grid[0][0] = game.Cross
+	res, err := s.NextMove(context.Background(), g, game.Nought)
+	if err != nil {
+		t.Fatalf("NextMove after the restart: %v", err)
+	}
+	if want := (game.Coord{Row: 0, Col: 1}); res.Coord != want {
+		t.Errorf("NextMove = %v, want %v", res.Coord, want)
+	}
+}
@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"context"
+	"tictactoe/internal/domain/game"
+)
+
+// FogName is the name of the fog-of-war strategy
+const FogName = "fog"
+
+type fogStrategy struct {
+	rnd game.RandomSource
+}
+
+// NewFogStrategy creates the strategy that plays fog games from its own view of the board
+func NewFogStrategy() Strategy {
+	return fogStrategy{rnd: game.NewRandomSource(0)}
+}
+
+// Name returns the name of the strategy
+func (fogStrategy) Name() string {
+	return FogName
+}
+
+// Description returns a short description of the strategy
+func (fogStrategy) Description() string {
+	return "guesses the hidden marks of fog games many times and plays the heuristic move chosen for most guesses"
+}
+
+// Supports reports that boards of the fog rule can be played
+func (fogStrategy) Supports(rules game.Rules) bool {
+	return rules.Variant == game.Classic && rules.Fog
+}
+
+// NextMove returns the move chosen by game.FogMove, the hits on the way are recorded in the game
+func (s fogStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	return resultOrError(game.FogMove(g, currentPlayer, s.rnd), 0)
+}
@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"context"
+	"tictactoe/internal/domain/game"
+)
+
+// HeuristicName is the name of the heuristic strategy
+const HeuristicName = "heuristic"
+
+type heuristicStrategy struct{}
+
+// NewHeuristicStrategy creates the strategy that plays by simple rules without search
+func NewHeuristicStrategy() Strategy {
+	return heuristicStrategy{}
+}
+
+// Name returns the name of the strategy
+func (heuristicStrategy) Name() string {
+	return HeuristicName
+}
+
+// Description returns a short description of the strategy
+func (heuristicStrategy) Description() string {
+	return "wins or blocks immediate threats, otherwise plays the cell with the best line potential"
+}
+
+// Supports reports that any board of the classic rules without the misere, wild and fog rules can be played
+func (heuristicStrategy) Supports(rules game.Rules) bool {
+	return rules.Variant == game.Classic && !rules.Misere && !rules.Wild && !rules.Fog
+}
+
+// NextMove returns the move chosen by game.HeuristicMove
+func (heuristicStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	return resultOrError(game.HeuristicMove(g, currentPlayer), 0)
+}
@@ -0,0 +1,43 @@
+package engine
+
+import "tictactoe/internal/domain/game"
+
+// Learner is implemented by strategies that learn from finished games
+type Learner interface {
+	Strategy
+	// Learn updates the strategy with the finished game in which it played the given mark
+	Learn(g *game.Game, player game.Mark)
+	// Curve returns the learning progress after every learned game
+	Curve() []LearningPoint
+	// MarshalState encodes the learned state to be persisted
+	MarshalState() ([]byte, error)
+	// UnmarshalState restores the learned state encoded by MarshalState
+	UnmarshalState(data []byte) error
+}
+
+// LearningPoint holds the total results of a learner after the given number of learned games
+type LearningPoint struct {
+	Games  int `json:"games"`
+	Wins   int `json:"wins"`
+	Draws  int `json:"draws"`
+	Losses int `json:"losses"`
+}
+
+// nextPoint returns the learning point that follows the last one of the curve after a game with the given winner
+func nextPoint(curve []LearningPoint, winner, player game.Mark) LearningPoint {
+	var point LearningPoint
+	if len(curve) > 0 {
+		point = curve[len(curve)-1]
+	}
+
+	point.Games++
+	switch winner {
+	case player:
+		point.Wins++
+	case game.Empty:
+		point.Draws++
+	default:
+		point.Losses++
+	}
+	return point
+}
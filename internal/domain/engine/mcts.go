@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"context"
+	"tictactoe/internal/config"
+	"tictactoe/internal/domain/game"
+)
+
+// MCTSName is the name of the Monte Carlo Tree Search strategy
+const MCTSName = "mcts"
+
+type mctsStrategy struct {
+	config game.MCTSConfig
+}
+
+// NewMCTSStrategy creates the Monte Carlo Tree Search strategy with the search budget from Config
+func NewMCTSStrategy(cfg *config.Config) Strategy {
+	return mctsStrategy{
+		config: game.MCTSConfig{
+			Iterations: cfg.MCTSIterations,
+			TimeLimit:  cfg.MCTSTimeLimit,
+			Seed:       cfg.MCTSSeed,
+		},
+	}
+}
+
+// Name returns the name of the strategy
+func (mctsStrategy) Name() string {
+	return MCTSName
+}
+
+// Description returns a short description of the strategy
+func (mctsStrategy) Description() string {
+	return "Monte Carlo Tree Search with UCT selection for larger boards and other variants"
+}
+
+// Supports reports that any board of any variant can be played, except for the wild and fog rules
+func (mctsStrategy) Supports(rules game.Rules) bool {
+	return !rules.Wild && !rules.Fog && !rules.Variant.MultiBoard() && rules.Variant != game.Morris
+}
+
+// NextMove returns the move found by game.MCTS within the configured budget.
+// The reported depth is the depth of the deepest expanded node of the search tree
+func (s mctsStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	coord, depth, err := game.MCTS(ctx, g, currentPlayer, s.config)
+	if err != nil {
+		return Result{Coord: game.NoCoord}, err
+	}
+	return Result{Coord: coord, Depth: depth}, nil
+}
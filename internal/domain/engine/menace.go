@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"tictactoe/internal/domain/game"
+	"time"
+)
+
+// MenaceName is the name of the MENACE strategy
+const MenaceName = "menace"
+
+// Bead changes of every chosen move at the end of a game
+const (
+	menaceWinBeads  = 3
+	menaceDrawBeads = 1
+	menaceLossBeads = -1
+)
+
+// menaceState is the learned state of MENACE: a matchbox of beads for every canonical position,
+// one bead count per cell in row-major order
+type menaceState struct {
+	Boxes map[game.Bitboard][]int `json:"boxes"`
+	Curve []LearningPoint         `json:"curve"`
+}
+
+type menaceStrategy struct {
+	mu    sync.Mutex
+	rnd   *rand.Rand
+	state menaceState
+}
+
+// NewMenaceStrategy creates the learning strategy modelled on the MENACE matchbox machine.
+// It starts playing randomly and learns from every finished game
+func NewMenaceStrategy() Strategy {
+	return &menaceStrategy{
+		rnd:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		state: menaceState{Boxes: map[game.Bitboard][]int{}},
+	}
+}
+
+// Name returns the name of the strategy
+func (s *menaceStrategy) Name() string {
+	return MenaceName
+}
+
+// Description returns a short description of the strategy
+func (s *menaceStrategy) Description() string {
+	return "matchbox machine that learns from finished games by adding and removing beads"
+}
+
+// Supports reports whether the board is the standard one
+func (s *menaceStrategy) Supports(rules game.Rules) bool {
+	return rules.IsStandard()
+}
+
+// NextMove draws a bead from the matchbox of the position: every empty cell is chosen
+// with the probability proportional to its beads. An emptied matchbox is refilled with one bead per cell
+func (s *menaceStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return resultOrError(game.NoCoord, 0)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, sym := g.Grid.CanonicalBitboard()
+	box := s.box(key)
+
+	total := 0
+	for _, beads := range box {
+		total += beads
+	}
+	if total == 0 {
+		canonical := key.Grid()
+		for cell := range box {
+			if canonical[cell/game.GridSize][cell%game.GridSize] == game.Empty {
+				box[cell] = 1
+				total++
+			}
+		}
+	}
+
+	bead := s.rnd.Intn(total)
+	for cell, beads := range box {
+		if bead < beads {
+			move := game.Coord{Row: cell / game.GridSize, Col: cell % game.GridSize}
+			return Result{Coord: sym.Inverse().MapCoord(move, game.GridSize)}, nil
+		}
+		bead -= beads
+	}
+	return resultOrError(game.NoCoord, 0)
+}
+
+// Learn replays the game and changes the beads of every move made by the player:
+// adds beads after a win or a draw and removes them after a loss
+func (s *menaceStrategy) Learn(g *game.Game, player game.Mark) {
+	if !g.Rules.IsStandard() {
+		return
+	}
+
+	delta := menaceLossBeads
+	switch g.Winner {
+	case player:
+		delta = menaceWinBeads
+	case game.Empty:
+		delta = menaceDrawBeads
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replay := game.NewGame()
+	for i, move := range g.Moves {
+		mover := game.Cross
+		if i%2 == 1 {
+			mover = game.Nought
+		}
+
+		if mover == player {
+			key, sym := replay.Grid.CanonicalBitboard()
+			c := sym.MapCoord(move, game.GridSize)
+			box := s.box(key)
+			box[c.Row*game.GridSize+c.Col] = max(box[c.Row*game.GridSize+c.Col]+delta, 0)
+		}
+		replay.Play(move, mover)
+	}
+
+	s.state.Curve = append(s.state.Curve, nextPoint(s.state.Curve, g.Winner, player))
+}
+
+// Curve returns the results after every learned game
+func (s *menaceStrategy) Curve() []LearningPoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]LearningPoint(nil), s.state.Curve...)
+}
+
+// MarshalState encodes the matchboxes and the learning curve to JSON
+func (s *menaceStrategy) MarshalState() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.MarshalIndent(s.state, "", "  ")
+}
+
+// UnmarshalState restores the matchboxes and the learning curve from JSON.
+// Returns an error if the JSON is malformed or a matchbox doesn't fit the standard board
+func (s *menaceStrategy) UnmarshalState(data []byte) error {
+	var state menaceState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to unmarshal menace state: %w", err)
+	}
+	if state.Boxes == nil {
+		state.Boxes = map[game.Bitboard][]int{}
+	}
+	for key, box := range state.Boxes {
+		if len(box) != standardCells || slices.Min(box) < 0 {
+			return fmt.Errorf("invalid menace matchbox of position %d", key)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+	return nil
+}
+
+// box returns the matchbox of the canonical position, filling a new one with the initial beads:
+// 4 beads per empty cell for the first moves down to 1 bead in the endgame
+func (s *menaceStrategy) box(key game.Bitboard) []int {
+	if box, ok := s.state.Boxes[key]; ok {
+		return box
+	}
+
+	grid := key.Grid()
+	ply := game.GridSize*game.GridSize - len((&game.Game{Grid: grid}).EmptyCells())
+	initial := max(4-ply/2, 1)
+
+	box := make([]int, game.GridSize*game.GridSize)
+	for cell := range box {
+		if grid[cell/game.GridSize][cell%game.GridSize] == game.Empty {
+			box[cell] = initial
+		}
+	}
+	s.state.Boxes[key] = box
+	return box
+}
@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"tictactoe/internal/domain/game"
+)
+
+// menaceGame returns a finished standard game with the moves made in order from the empty board
+func menaceGame(moves ...game.Coord) *game.Game {
+	g := game.NewGame()
+	for i, move := range moves {
+		mover := game.Cross
+		if i%2 == 1 {
+			mover = game.Nought
+		}
+		g.Play(move, mover)
+	}
+	_, g.Winner = g.IsOver()
+	return g
+}
+
+// crossWins is a game won by Cross on the top row
+var crossWins = []game.Coord{{Row: 0, Col: 0}, {Row: 1, Col: 0}, {Row: 0, Col: 1}, {Row: 1, Col: 1}, {Row: 0, Col: 2}}
+
+func TestMenaceLearn(t *testing.T) {
+	tests := []struct {
+		player game.Mark
+		delta  int
+	}{
+		{player: game.Cross, delta: menaceWinBeads},
+		{player: game.Nought, delta: menaceLossBeads},
+	}
+
+	for _, tt := range tests {
+		s := NewMenaceStrategy().(*menaceStrategy)
+		g := menaceGame(crossWins...)
+
+		// beads of the moves of the player before learning, in the canonical positions
+		type cell struct {
+			key   game.Bitboard
+			index int
+		}
+		before := map[cell]int{}
+		replay := game.NewGame()
+		for i, move := range g.Moves {
+			mover := game.Cross
+			if i%2 == 1 {
+				mover = game.Nought
+			}
+			if mover == tt.player {
+				key, sym := replay.Grid.CanonicalBitboard()
+				c := sym.MapCoord(move, game.GridSize)
+				index := c.Row*game.GridSize + c.Col
+				before[cell{key, index}] = s.box(key)[index]
+			}
+			replay.Play(move, mover)
+		}
+
+		s.Learn(g, tt.player)
+		for c, beads := range before {
+			if got, want := s.state.Boxes[c.key][c.index], max(beads+tt.delta, 0); got != want {
+				t.Errorf("player %v: %d beads of cell %d in %v, want %d", tt.player, got, c.index, c.key.Grid(), want)
+			}
+		}
+	}
+}
+
+func TestMenaceCurve(t *testing.T) {
+	s := NewMenaceStrategy().(*menaceStrategy)
+	s.Learn(menaceGame(crossWins...), game.Cross)
+	s.Learn(menaceGame(crossWins...), game.Nought)
+	draw := []game.Coord{
+		{Row: 0, Col: 0}, {Row: 1, Col: 1}, {Row: 2, Col: 2}, {Row: 0, Col: 1}, {Row: 2, Col: 1},
+		{Row: 2, Col: 0}, {Row: 0, Col: 2}, {Row: 1, Col: 2}, {Row: 1, Col: 0},
+	}
+	s.Learn(menaceGame(draw...), game.Cross)
+
+	want := []LearningPoint{
+		{Games: 1, Wins: 1},
+		{Games: 2, Wins: 1, Losses: 1},
+		{Games: 3, Wins: 1, Draws: 1, Losses: 1},
+	}
+	if got := s.Curve(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Curve = %v, want %v", got, want)
+	}
+}
+
+func TestMenaceStateRoundTrip(t *testing.T) {
+	s := NewMenaceStrategy().(*menaceStrategy)
+	s.Learn(menaceGame(crossWins...), game.Cross)
+	s.Learn(menaceGame(crossWins...), game.Nought)
+
+	data, err := s.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState: %v", err)
+	}
+	restored := NewMenaceStrategy().(*menaceStrategy)
+	if err := restored.UnmarshalState(data); err != nil {
+		t.Fatalf("UnmarshalState: %v", err)
+	}
+	if !reflect.DeepEqual(restored.state, s.state) {
+		t.Errorf("restored state %v, want %v", restored.state, s.state)
+	}
+}
+
+func TestMenaceUnmarshalStateInvalid(t *testing.T) {
+	states := []string{
+		`not json`,
+		`{"boxes": {"0": [1, 2, 3]}}`,
+		`{"boxes": {"0": [1, 1, 1, 1, -1, 1, 1, 1, 1]}}`,
+		`{"boxes": "none"}`,
+	}
+	for _, state := range states {
+		s := NewMenaceStrategy().(*menaceStrategy)
+		if err := s.UnmarshalState([]byte(state)); err == nil {
+			t.Errorf("state %s restored", state)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"tictactoe/internal/config"
+	"tictactoe/internal/datasource"
+	"tictactoe/internal/domain/game"
+)
+
+// MinimaxName is the name of the minimax strategy
+const MinimaxName = "minimax"
+
+type minimaxStrategy struct {
+	workers int
+	rnd     game.RandomSource
+	book    *game.Book
+}
+
+// NewMinimaxStrategy creates the strategy that plays perfectly by the tablebase and Minimax search
+// on the standard board and by time-bounded iterative deepening search on larger ones.
+// Searches run on the number of goroutines set in Config, equally good moves on the standard board
+// are chosen at random from the source seeded by Config. The opening book from the file set in Config
+// is consulted first on the standard board, without the file the strategy plays without a book.
+// Returns an error if the book file exists but can't be read or parsed
+func NewMinimaxStrategy(cfg *config.Config) (Strategy, error) {
+	book, err := datasource.LoadOpeningBook(cfg.BookFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		book = nil
+	} else if err != nil {
+		return nil, err
+	}
+	return minimaxStrategy{workers: cfg.SearchWorkers, rnd: game.NewRandomSource(cfg.MinimaxSeed), book: book}, nil
+}
+
+// Name returns the name of the strategy
+func (minimaxStrategy) Name() string {
+	return MinimaxName
+}
+
+// Description returns a short description of the strategy
+func (minimaxStrategy) Description() string {
+	return "perfect play on the standard board, notakto and morris, time-bounded iterative deepening alpha-beta search on larger boards"
+}
+
+// Supports reports that any board of the classic rules without the fog rule, notakto and morris can be played
+func (minimaxStrategy) Supports(rules game.Rules) bool {
+	return (rules.Variant == game.Classic && !rules.Fog) || rules.Variant == game.Notakto || rules.Variant == game.Morris
+}
+
+// NextMove returns an optimal move for the current player on the standard board.
+// On larger boards returns the best move found by game.IterativeDeepening before ctx is done,
+// in wild games the best cell and mark found by game.WildMove and in notakto games the move of game.NotaktoMove.
+// A solved notakto position reports the depth of all empty cells of the live boards.
+// Morris games are played by game.MorrisMove, the depth is the distance to the end of a decided game
+func (s minimaxStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	if g.Rules.Variant == game.Morris {
+		step, depth, err := game.MorrisMove(g)
+		if err != nil {
+			return Result{Coord: game.NoCoord}, err
+		}
+		return Result{Coord: step.To, Depth: depth, From: step.From}, nil
+	}
+
+	if g.Rules.Variant == game.Notakto {
+		move, solved, err := game.NotaktoMove(ctx, g)
+		if err != nil {
+			return Result{Coord: game.NoCoord}, err
+		}
+		res := Result{Coord: move.Cell(), Board: move.Board}
+		if solved {
+			res.Depth = len(g.LegalBoardMoves())
+		}
+		return res, nil
+	}
+
+	if g.Rules.Wild {
+		coord, mark, depth, err := game.WildMove(ctx, g)
+		if err != nil {
+			return Result{Coord: game.NoCoord}, err
+		}
+		return Result{Coord: coord, Depth: depth, Mark: mark}, nil
+	}
+
+	if !g.Rules.IsStandard() {
+		coord, depth, err := game.IterativeDeepening(ctx, g, currentPlayer, s.workers)
+		if err != nil {
+			return Result{Coord: game.NoCoord}, err
+		}
+		return Result{Coord: coord, Depth: depth}, nil
+	}
+
+	coord, err := g.NextMove(currentPlayer, s.book, s.workers, s.rnd)
+	if err != nil {
+		return Result{Coord: game.NoCoord}, err
+	}
+	return Result{Coord: coord, Depth: len(g.EmptyCells())}, nil
+}
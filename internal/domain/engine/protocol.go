@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tictactoe/internal/domain/game"
+	"time"
+)
+
+// The external engine protocol is line based. The server writes one request per line
+// to the engine stdin and reads one reply line from its stdout:
+//
+//	position <size> <winLength> <player> <movetime> <cells>
+//	move <row> <col>
+//
+// player is X or O, movetime is the time budget of the move in milliseconds and cells
+// lists size*size marks row by row: X, O or '.' for an empty cell. An engine that can't
+// find a move replies with
+//
+//	error <message>
+//
+// The engine must exit when its stdin is closed.
+
+// markSymbols maps marks to their protocol symbols
+var markSymbols = map[game.Mark]byte{game.Empty: '.', game.Cross: 'X', game.Nought: 'O'}
+
+// FormatPosition returns the protocol request for the move of the current player
+func FormatPosition(g *game.Game, currentPlayer game.Mark, moveTime time.Duration) string {
+	var cells strings.Builder
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
+			cells.WriteByte(markSymbols[g.Grid[i][j]])
+		}
+	}
+
+	return fmt.Sprintf("position %d %d %c %d %s",
+		len(g.Grid), g.Rules.WinLength, markSymbols[currentPlayer], moveTime.Milliseconds(), cells.String())
+}
+
+// ParsePosition parses a protocol request into the game, the current player and the move time budget.
+// Returns an error if the request is malformed
+func ParsePosition(line string) (*game.Game, game.Mark, time.Duration, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 6 || fields[0] != "position" {
+		return nil, game.Empty, 0, fmt.Errorf("malformed position: %q", line)
+	}
+
+	size, errSize := strconv.Atoi(fields[1])
+	winLength, errWin := strconv.Atoi(fields[2])
+	moveTime, errTime := strconv.Atoi(fields[4])
+	if errSize != nil || errWin != nil || errTime != nil {
+		return nil, game.Empty, 0, fmt.Errorf("malformed position: %q", line)
+	}
+
+	g, err := game.NewGameWithRules(game.Rules{Size: size, WinLength: winLength})
+	if err != nil {
+		return nil, game.Empty, 0, err
+	}
+
+	currentPlayer, ok := parseMark(fields[3])
+	if !ok || currentPlayer == game.Empty {
+		return nil, game.Empty, 0, fmt.Errorf("invalid player: %q", fields[3])
+	}
+
+	cells := fields[5]
+	if len(cells) != size*size {
+		return nil, game.Empty, 0, fmt.Errorf("expected %d cells, got %d", size*size, len(cells))
+	}
+	for k := 0; k < len(cells); k++ {
+		mark, ok := parseMark(cells[k : k+1])
+		if !ok {
+			return nil, game.Empty, 0, fmt.Errorf("invalid cell: %q", cells[k])
+		}
+		g.Grid[k/size][k%size] = mark
+	}
+
+	return g, currentPlayer, time.Duration(moveTime) * time.Millisecond, nil
+}
+
+// FormatMove returns the protocol reply with the move
+func FormatMove(coord game.Coord) string {
+	return fmt.Sprintf("move %d %d", coord.Row, coord.Col)
+}
+
+// ParseMove parses a protocol reply. Returns an error if the reply is malformed
+// or the engine reported an error
+func ParseMove(line string) (game.Coord, error) {
+	if msg, ok := strings.CutPrefix(line, "error"); ok {
+		return game.NoCoord, fmt.Errorf("engine error: %s", strings.TrimSpace(msg))
+	}
+
+	var coord game.Coord
+	if _, err := fmt.Sscanf(line, "move %d %d", &coord.Row, &coord.Col); err != nil {
+		return game.NoCoord, fmt.Errorf("malformed move: %q", line)
+	}
+	return coord, nil
+}
+
+// parseMark returns the mark of a protocol symbol
+func parseMark(symbol string) (game.Mark, bool) {
+	for mark, s := range markSymbols {
+		if symbol == string(s) {
+			return mark, true
+		}
+	}
+	return game.Empty, false
+}
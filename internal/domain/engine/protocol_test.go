@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"testing"
+	"tictactoe/internal/domain/game"
+	"time"
+)
+
+func TestPositionRoundTrip(t *testing.T) {
+	g, err := game.NewGameWithRules(game.Rules{Size: 4, WinLength: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Grid[0][1], g.Grid[2][3], g.Grid[3][0] = game.Cross, game.Nought, game.Cross
+
+	line := FormatPosition(g, game.Nought, 1500*time.Millisecond)
+	if want := "position 4 3 O 1500 .X.........OX..."; line != want {
+		t.Fatalf("FormatPosition = %q, want %q", line, want)
+	}
+
+	parsed, player, moveTime, err := ParsePosition(line)
+	if err != nil {
+		t.Fatalf("ParsePosition: %v", err)
+	}
+	if !parsed.Grid.Equal(g.Grid) || parsed.Rules.WinLength != 3 || player != game.Nought || moveTime != 1500*time.Millisecond {
+		t.Errorf("ParsePosition = %v, win length %d, %v, %v", parsed.Grid, parsed.Rules.WinLength, player, moveTime)
+	}
+}
+
+func TestParsePositionMalformed(t *testing.T) {
+	lines := []string{
+		"",
+		"move 1 1",
+		"position 3 3 X 100",
+		"position 3 3 Z 100 .........",
+		"position 3 3 X 100 ........",
+		"position 3 3 X 100 ....#....",
+		"position three 3 X 100 .........",
+	}
+	for _, line := range lines {
+		if _, _, _, err := ParsePosition(line); err == nil {
+			t.Errorf("ParsePosition(%q) accepted", line)
+		}
+	}
+}
+
+func TestMoveRoundTrip(t *testing.T) {
+	coord := game.Coord{Row: 2, Col: 7}
+	parsed, err := ParseMove(FormatMove(coord))
+	if err != nil || parsed != coord {
+		t.Errorf("ParseMove(FormatMove(%v)) = %v, %v", coord, parsed, err)
+	}
+
+	for _, line := range []string{"error no move", "move", "move 1", "bogus"} {
+		if _, err := ParseMove(line); err == nil {
+			t.Errorf("ParseMove(%q) accepted", line)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"tictactoe/internal/config"
+	"tictactoe/internal/domain/game"
+)
+
+// QLearningName is the name of the strategy that plays by a trained policy
+const QLearningName = "qlearning"
+
+// standardCells is the number of cells of the standard board
+const standardCells = game.GridSize * game.GridSize
+
+// TrainConfig holds the parameters of self-play training
+type TrainConfig struct {
+	Episodes int     // Number of self-play games
+	Seed     int64   // Seed of the exploration; equal seeds give equal policies
+	Alpha    float64 // Learning rate
+	Gamma    float64 // Discount of values of later positions
+	Epsilon  float64 // Probability of an exploring random move
+}
+
+// Policy holds the values of moves in canonical positions of the standard board.
+// A value is the expected result for the player to move, from -1 for a loss to 1 for a win
+type Policy struct {
+	Episodes int                                      `json:"episodes"`
+	Seed     int64                                    `json:"seed"`
+	Values   map[game.Bitboard][standardCells]float64 `json:"values"`
+}
+
+// TrainPolicy learns a policy by tabular Q-learning in self-play games on the standard board.
+// Both sides share the table: the value of a move is the negated value of the opponent's best reply.
+// The result depends only on the config, so training with the same seed is reproducible
+func TrainPolicy(cfg TrainConfig) *Policy {
+	p := Policy{Episodes: cfg.Episodes, Seed: cfg.Seed, Values: map[game.Bitboard][standardCells]float64{}}
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+
+	for episode := 0; episode < cfg.Episodes; episode++ {
+		g := game.NewGame()
+		currentPlayer := game.Cross
+
+		for {
+			key, _ := g.Grid.CanonicalBitboard()
+			g.Grid = key.Grid()
+
+			move := p.bestMove(g)
+			if rnd.Float64() < cfg.Epsilon {
+				empty := g.EmptyCells()
+				move = empty[rnd.Intn(len(empty))]
+			}
+			g.Play(move, currentPlayer)
+
+			target := 0.0
+			gameOver, winner := g.IsOver()
+			if winner == currentPlayer {
+				target = 1
+			} else if !gameOver {
+				next, _ := g.Grid.CanonicalBitboard()
+				target = -cfg.Gamma * p.value(&game.Game{Grid: next.Grid(), Rules: g.Rules})
+			}
+
+			values := p.Values[key]
+			cell := move.Row*game.GridSize + move.Col
+			values[cell] += cfg.Alpha * (target - values[cell])
+			p.Values[key] = values
+
+			if gameOver {
+				break
+			}
+			currentPlayer = game.GetOpponent(currentPlayer)
+		}
+	}
+
+	return &p
+}
+
+// bestMove returns the empty cell with the highest value in the canonical position,
+// the first one in row-major order among equal values
+func (p *Policy) bestMove(g *game.Game) game.Coord {
+	values := p.Values[g.Grid.Bitboard()]
+	bestCoord, bestValue := game.NoCoord, math.Inf(-1)
+	for _, c := range g.EmptyCells() {
+		if v := values[c.Row*game.GridSize+c.Col]; v > bestValue {
+			bestCoord, bestValue = c, v
+		}
+	}
+	return bestCoord
+}
+
+// value returns the value of the best move in the canonical position
+func (p *Policy) value(g *game.Game) float64 {
+	c := p.bestMove(g)
+	return p.Values[g.Grid.Bitboard()][c.Row*game.GridSize+c.Col]
+}
+
+// MarshalPolicy encodes the policy to JSON
+func MarshalPolicy(p *Policy) ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// UnmarshalPolicy decodes a policy encoded by MarshalPolicy
+func UnmarshalPolicy(data []byte) (*Policy, error) {
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy: %w", err)
+	}
+	if p.Values == nil {
+		p.Values = map[game.Bitboard][standardCells]float64{}
+	}
+	return &p, nil
+}
+
+type qlearningStrategy struct {
+	policy *Policy
+}
+
+// NewQLearningStrategy creates the strategy that plays by the policy from the file set in Config.
+// Without the file it plays like the heuristic strategy.
+// Returns an error if the file exists but can't be read or decoded
+func NewQLearningStrategy(cfg *config.Config) (Strategy, error) {
+	data, err := os.ReadFile(cfg.PolicyFile)
+	if os.IsNotExist(err) {
+		return qlearningStrategy{policy: &Policy{Values: map[game.Bitboard][standardCells]float64{}}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	policy, err := UnmarshalPolicy(data)
+	if err != nil {
+		return nil, err
+	}
+	return qlearningStrategy{policy: policy}, nil
+}
+
+// Name returns the name of the strategy
+func (qlearningStrategy) Name() string {
+	return QLearningName
+}
+
+// Description returns a short description of the strategy
+func (s qlearningStrategy) Description() string {
+	return fmt.Sprintf("policy trained by Q-learning in %d self-play games", s.policy.Episodes)
+}
+
+// Supports reports whether the board is the standard one
+func (qlearningStrategy) Supports(rules game.Rules) bool {
+	return rules.IsStandard()
+}
+
+// NextMove returns the move with the highest value in the policy.
+// Positions missing in the policy are played by game.HeuristicMove
+func (s qlearningStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return resultOrError(game.NoCoord, 0)
+	}
+
+	key, sym := g.Grid.CanonicalBitboard()
+	if _, ok := s.policy.Values[key]; !ok {
+		return resultOrError(game.HeuristicMove(g, currentPlayer), 0)
+	}
+
+	move := s.policy.bestMove(&game.Game{Grid: key.Grid(), Rules: g.Rules})
+	return resultOrError(sym.Inverse().MapCoord(move, game.GridSize), 0)
+}
@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"tictactoe/internal/config"
+	"tictactoe/internal/domain/game"
+)
+
+// testTrainConfig returns the parameters of cmd/train with fewer episodes and the given seed
+func testTrainConfig(seed int64) TrainConfig {
+	return TrainConfig{Episodes: 2000, Seed: seed, Alpha: 0.3, Gamma: 0.95, Epsilon: 0.3}
+}
+
+func TestTrainPolicySeed(t *testing.T) {
+	first := TrainPolicy(testTrainConfig(1))
+	second := TrainPolicy(testTrainConfig(1))
+	if !reflect.DeepEqual(first, second) {
+		t.Error("policies trained with the same seed differ")
+	}
+
+	other := TrainPolicy(testTrainConfig(2))
+	if reflect.DeepEqual(first.Values, other.Values) {
+		t.Error("policies trained with different seeds are equal")
+	}
+}
+
+func TestQLearningStrategyLegalMoves(t *testing.T) {
+	data, err := MarshalPolicy(TrainPolicy(testTrainConfig(1)))
+	if err != nil {
+		t.Fatalf("MarshalPolicy: %v", err)
+	}
+	policy, err := UnmarshalPolicy(data)
+	if err != nil {
+		t.Fatalf("UnmarshalPolicy: %v", err)
+	}
+	if len(policy.Values) == 0 || policy.Episodes != 2000 {
+		t.Fatalf("decoded policy has %d positions of %d episodes", len(policy.Values), policy.Episodes)
+	}
+
+	file := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewQLearningStrategy(&config.Config{PolicyFile: file})
+	if err != nil {
+		t.Fatalf("NewQLearningStrategy: %v", err)
+	}
+	if got := s.(qlearningStrategy).policy; !reflect.DeepEqual(got, policy) {
+		t.Fatal("strategy didn't load the policy from the file")
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		g := game.NewGame()
+		learner := game.Cross
+		if i%2 == 1 {
+			learner = game.Nought
+		}
+
+		for player := game.Cross; ; player = game.GetOpponent(player) {
+			if gameOver, _ := g.IsOver(); gameOver {
+				break
+			}
+
+			var move game.Coord
+			if player == learner {
+				result, err := s.NextMove(context.Background(), g, player)
+				if err != nil {
+					t.Fatalf("game %d: NextMove: %v", i, err)
+				}
+				move = result.Coord
+			} else {
+				empty := g.EmptyCells()
+				move = empty[rnd.Intn(len(empty))]
+			}
+
+			if err := g.SetPlayerMove(move, player); err != nil {
+				t.Fatalf("game %d: move %v of %v is not legal: %v", i, move, player, err)
+			}
+		}
+	}
+}
+
+func TestNewQLearningStrategyPolicyFile(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte(`{"values": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{name: "missing file", file: filepath.Join(dir, "missing.json")},
+		{name: "corrupt file", file: corrupt, wantErr: true},
+		{name: "directory", file: dir, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewQLearningStrategy(&config.Config{PolicyFile: tt.file})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewQLearningStrategy error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"tictactoe/internal/domain/game"
+	"time"
+)
+
+// RandomName is the name of the random strategy
+const RandomName = "random"
+
+type randomStrategy struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewRandomStrategy creates the strategy that plays a random empty cell
+func NewRandomStrategy() Strategy {
+	return &randomStrategy{rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// Name returns the name of the strategy
+func (s *randomStrategy) Name() string {
+	return RandomName
+}
+
+// Description returns a short description of the strategy
+func (s *randomStrategy) Description() string {
+	return "uniformly random legal move"
+}
+
+// Supports reports that any board of any variant can be played
+func (s *randomStrategy) Supports(rules game.Rules) bool {
+	return true
+}
+
+// NextMove returns a random cell among the legal moves, with a random mark in wild games
+// and on a random board in multi-board games. Morris games get a random step,
+// fog games a random cell among the ones the player sees empty, the hits on the way are recorded in the game
+func (s *randomStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	if g.Rules.Variant == game.Morris {
+		steps := g.LegalSteps()
+		if gameOver, _ := g.IsOver(); gameOver || len(steps) == 0 {
+			return resultOrError(game.NoCoord, 0)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		step := steps[s.rnd.Intn(len(steps))]
+		return Result{Coord: step.To, From: step.From}, nil
+	}
+
+	if g.Rules.Variant.MultiBoard() {
+		moves := g.LegalBoardMoves()
+		if gameOver, _ := g.IsOver(); gameOver || len(moves) == 0 {
+			return resultOrError(game.NoCoord, 0)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		move := moves[s.rnd.Intn(len(moves))]
+		return Result{Coord: move.Cell(), Board: move.Board}, nil
+	}
+
+	if g.Rules.Fog {
+		if gameOver, _ := g.IsOver(); gameOver {
+			return resultOrError(game.NoCoord, 0)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return resultOrError(g.FogProbe(currentPlayer, func(view game.Grid) game.Coord {
+			cells := (&game.Game{Grid: view}).EmptyCells()
+			if len(cells) == 0 {
+				return game.NoCoord
+			}
+			return cells[s.rnd.Intn(len(cells))]
+		}), 0)
+	}
+
+	cells := g.LegalMoves()
+	if gameOver, _ := g.IsOver(); gameOver || len(cells) == 0 {
+		return resultOrError(game.NoCoord, 0)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := Result{Coord: cells[s.rnd.Intn(len(cells))]}
+	if g.Rules.Wild {
+		res.Mark = [2]game.Mark{game.Cross, game.Nought}[s.rnd.Intn(2)]
+	}
+	return res, nil
+}
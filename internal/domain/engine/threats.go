@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"context"
+	"tictactoe/internal/domain/game"
+)
+
+// ThreatsName is the name of the threat-based strategy
+const ThreatsName = "threats"
+
+type threatsStrategy struct{}
+
+// NewThreatsStrategy creates the strategy that plays by the threats of its moves without search
+func NewThreatsStrategy() Strategy {
+	return threatsStrategy{}
+}
+
+// Name returns the name of the strategy
+func (threatsStrategy) Name() string {
+	return ThreatsName
+}
+
+// Description returns a short description of the strategy
+func (threatsStrategy) Description() string {
+	return "threat-based play for gomoku: wins, blocks, builds open fours and threes and spoils the opponent's ones; threat-space search in qubic"
+}
+
+// Supports reports that gomoku, qubic and boards of the classic rules without the misere, wild and fog rules can be played
+func (threatsStrategy) Supports(rules game.Rules) bool {
+	return (rules.Variant == game.Classic || rules.Variant == game.Gomoku || rules.Variant == game.Qubic) && !rules.Misere && !rules.Wild && !rules.Fog
+}
+
+// NextMove returns the move chosen by game.ThreatMove, in qubic games by game.QubicMove
+// with the length of the forced win it starts as the depth
+func (threatsStrategy) NextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (Result, error) {
+	if g.Rules.Variant == game.Qubic {
+		move, depth := game.QubicMove(ctx, g, currentPlayer)
+		res, err := resultOrError(move.Cell(), depth)
+		res.Board = move.Board
+		return res, err
+	}
+	return resultOrError(game.ThreatMove(g, currentPlayer), 0)
+}
@@ -0,0 +1,91 @@
+package game
+
+import "fmt"
+
+// BoardCoord addresses a cell of one of the boards of a multi-board game
+type BoardCoord struct {
+	Board    int // Board index (0-based), the layer of qubic games
+	Row, Col int
+}
+
+// Cell returns the cell of the coordinate on its board
+func (c BoardCoord) Cell() Coord {
+	return Coord{Row: c.Row, Col: c.Col}
+}
+
+// NoBoardCoord represents an invalid or undefined coordinate of a multi-board game
+var NoBoardCoord = BoardCoord{Board: -1, Row: -1, Col: -1}
+
+// MultiBoard reports whether games of the variant are played on Game.Boards instead of Game.Grid
+func (v Variant) MultiBoard() bool {
+	return v == Notakto || v == Qubic
+}
+
+// newBoards returns the given number of empty boards with size rows and cols
+func newBoards(count, size int) []Grid {
+	boards := make([]Grid, count)
+	for i := range boards {
+		boards[i] = NewGrid(size)
+	}
+	return boards
+}
+
+// SetBoardMove checks the move of the current player in a multi-board game and sets the mark
+// to the cell of the board: a cross in notakto games, the player's own mark in qubic games.
+// Returns an error if the move is not possible
+func (g *Game) SetBoardMove(move BoardCoord, currentPlayer Mark) error {
+	if !g.Rules.Variant.MultiBoard() {
+		return fmt.Errorf("no move possible: boards are only chosen in notakto and qubic games")
+	}
+
+	gameOver, _ := g.IsOver()
+	if gameOver {
+		g.State = Completed
+		return fmt.Errorf("no move possible: game is over")
+	}
+
+	size := g.Rules.Size
+	if move.Board < 0 || move.Board >= len(g.Boards) || move.Row < 0 || move.Row >= size || move.Col < 0 || move.Col >= size {
+		return fmt.Errorf("no move possible: no such cell")
+	}
+
+	if g.Boards[move.Board][move.Row][move.Col] != Empty {
+		return fmt.Errorf("no move possible: cell is occupied")
+	}
+
+	mark := currentPlayer
+	if g.Rules.Variant == Notakto {
+		if g.BoardDead(move.Board) {
+			return fmt.Errorf("no move possible: board is dead")
+		}
+		mark = Cross
+	}
+
+	g.PlayBoard(move, mark)
+	return nil
+}
+
+// PlayBoard sets the mark to the cell of the board and records the move without any checks
+func (g *Game) PlayBoard(move BoardCoord, mark Mark) {
+	g.Boards[move.Board][move.Row][move.Col] = mark
+	g.BoardMoves = append(g.BoardMoves, move)
+}
+
+// LegalBoardMoves returns the cells the next move of a multi-board game can be made in:
+// the empty cells board by board in row-major order, skipping the dead boards of notakto games
+func (g *Game) LegalBoardMoves() []BoardCoord {
+	var moves []BoardCoord
+	for b, board := range g.Boards {
+		if g.Rules.Variant == Notakto && g.BoardDead(b) {
+			continue
+		}
+		for i := range board {
+			for j := range board[i] {
+				if board[i][j] == Empty {
+					moves = append(moves, BoardCoord{Board: b, Row: i, Col: j})
+				}
+			}
+		}
+	}
+	return moves
+}
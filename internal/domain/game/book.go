@@ -0,0 +1,257 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// BookMove is a move of an opening book with its weight
+type BookMove struct {
+	Move   Coord
+	Weight int
+}
+
+// Book is an opening book of the standard board: weighted moves for positions.
+// Positions and moves are kept in the canonical orientation, so a book entry
+// serves all symmetric images of its position.
+//
+// In the text format every line holds a position and its moves:
+//
+//	<cells> <row>,<col>:<weight> ...
+//
+// cells lists the marks row by row: X, O or '.' for an empty cell.
+// Empty lines and lines starting with # are ignored.
+type Book struct {
+	positions map[Bitboard][]BookMove
+}
+
+// NewBook returns an empty opening book
+func NewBook() *Book {
+	return &Book{positions: map[Bitboard][]BookMove{}}
+}
+
+// Add adds the weight to the move in the position given in any orientation
+func (b *Book) Add(grid Grid, move Coord, weight int) {
+	key, sym := grid.CanonicalBitboard()
+	move = sym.MapCoord(move, GridSize)
+
+	moves := b.positions[key]
+	for i := range moves {
+		if moves[i].Move == move {
+			moves[i].Weight += weight
+			return
+		}
+	}
+	b.positions[key] = append(moves, BookMove{Move: move, Weight: weight})
+}
+
+// Moves returns the book moves for the position in its own orientation
+func (b *Book) Moves(grid Grid) []BookMove {
+	key, sym := grid.CanonicalBitboard()
+	inverse := sym.Inverse()
+
+	var moves []BookMove
+	for _, m := range b.positions[key] {
+		moves = append(moves, BookMove{Move: inverse.MapCoord(m.Move, GridSize), Weight: m.Weight})
+	}
+	return moves
+}
+
+// Len returns the number of positions in the book
+func (b *Book) Len() int {
+	return len(b.positions)
+}
+
+// BuildBook collects the first depth moves of completed standard games into a book.
+// A move is weighted by the result of its side: 2 for a win, 1 for a draw, lost games add nothing
+func BuildBook(games []*Game, depth int) *Book {
+	b := NewBook()
+	for _, g := range games {
+		if g.State != Completed || !g.Rules.IsStandard() {
+			continue
+		}
+
+		replay := NewGame()
+		for i, move := range g.Moves {
+			if i >= depth {
+				break
+			}
+
+			mover := Cross
+			if i%2 == 1 {
+				mover = Nought
+			}
+
+			if g.Winner == mover {
+				b.Add(replay.Grid, move, 2)
+			} else if g.Winner == Empty {
+				b.Add(replay.Grid, move, 1)
+			}
+			replay.Play(move, mover)
+		}
+	}
+	return b
+}
+
+// ParseBook reads a book in the text format.
+// Returns an error if a line is malformed
+func ParseBook(r io.Reader) (*Book, error) {
+	b := NewBook()
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		grid, err := parseCells(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		for _, field := range fields[1:] {
+			var move Coord
+			var weight int
+			if _, err := fmt.Sscanf(field, "%d,%d:%d", &move.Row, &move.Col, &weight); err != nil {
+				return nil, fmt.Errorf("line %d: malformed move %q", line, field)
+			}
+			if move.Row < 0 || move.Row >= GridSize || move.Col < 0 || move.Col >= GridSize ||
+				grid[move.Row][move.Col] != Empty || weight < 0 {
+				return nil, fmt.Errorf("line %d: invalid move %q", line, field)
+			}
+			b.Add(grid, move, weight)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Write writes the book in the text format, positions sorted by key and moves by weight
+func (b *Book) Write(w io.Writer) error {
+	keys := make([]Bitboard, 0, len(b.positions))
+	for key := range b.positions {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	for _, key := range keys {
+		moves := append([]BookMove(nil), b.positions[key]...)
+		sort.SliceStable(moves, func(i, j int) bool { return moves[i].Weight > moves[j].Weight })
+
+		line := []string{formatCells(key.Grid())}
+		for _, m := range moves {
+			line = append(line, fmt.Sprintf("%d,%d:%d", m.Move.Row, m.Move.Col, m.Weight))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseCells parses a standard grid written row by row as X, O and '.'
+func parseCells(cells string) (Grid, error) {
+	if len(cells) != GridSize*GridSize {
+		return nil, fmt.Errorf("expected %d cells, got %d", GridSize*GridSize, len(cells))
+	}
+
+	grid := NewGrid(GridSize)
+	for k := 0; k < len(cells); k++ {
+		switch cells[k] {
+		case '.':
+		case 'X':
+			grid[k/GridSize][k%GridSize] = Cross
+		case 'O':
+			grid[k/GridSize][k%GridSize] = Nought
+		default:
+			return nil, fmt.Errorf("invalid cell %q", cells[k])
+		}
+	}
+	return grid, nil
+}
+
+// formatCells writes the grid row by row as X, O and '.'
+func formatCells(grid Grid) string {
+	var sb strings.Builder
+	for i := range grid {
+		for j := range grid[i] {
+			sb.WriteByte(".XO"[grid[i][j]])
+		}
+	}
+	return sb.String()
+}
+
+// choose chooses a move of the standard game from the book by rnd, proportionally to the weights,
+// or the heaviest move, the first in the book among equal ones, if rnd is nil.
+// Moves that spoil the perfect-play outcome of the position are skipped.
+// The last value is false if the book is nil or has no suitable move
+func (b *Book) choose(g *Game, currentPlayer Mark, rnd RandomSource) (Coord, bool) {
+	if b == nil || !g.Rules.IsStandard() {
+		return NoCoord, false
+	}
+
+	_, outcome, solved := LookupTablebase(g, currentPlayer)
+
+	var candidates []BookMove
+	total := 0
+	for _, m := range b.Moves(g.Grid) {
+		if m.Weight == 0 || g.Grid[m.Move.Row][m.Move.Col] != Empty {
+			continue
+		}
+		if solved && childOutcome(g, m.Move, currentPlayer) != outcome {
+			continue
+		}
+		candidates = append(candidates, m)
+		total += m.Weight
+	}
+
+	if total == 0 {
+		return NoCoord, false
+	}
+
+	if rnd == nil {
+		heaviest := candidates[0]
+		for _, m := range candidates {
+			if m.Weight > heaviest.Weight {
+				heaviest = m
+			}
+		}
+		return heaviest.Move, true
+	}
+
+	pick := rnd.Intn(total)
+	for _, m := range candidates {
+		if pick < m.Weight {
+			return m.Move, true
+		}
+		pick -= m.Weight
+	}
+	return NoCoord, false
+}
+
+// childOutcome returns the perfect-play outcome of the standard position after the move
+func childOutcome(g *Game, move Coord, currentPlayer Mark) Outcome {
+	child := g.Clone()
+	child.Play(move, currentPlayer)
+
+	if gameOver, winner := child.IsOver(); gameOver {
+		switch winner {
+		case Cross:
+			return CrossWins
+		case Nought:
+			return NoughtWins
+		}
+		return Draw
+	}
+
+	_, outcome, _ := LookupTablebase(child, GetOpponent(currentPlayer))
+	return outcome
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// bookText is a book with a position in a non-canonical orientation and a comment
+const bookText = `# first moves
+......... 1,1:5 0,0:3
+
+X........ 1,1:4
+..X...... 1,1:2 0,0:1
+`
+
+func TestParseBook(t *testing.T) {
+	b, err := ParseBook(strings.NewReader(bookText))
+	if err != nil {
+		t.Fatalf("ParseBook: %v", err)
+	}
+	if b.Len() != 2 {
+		t.Errorf("Len = %d, want 2: symmetric positions share an entry", b.Len())
+	}
+
+	// X in the top right corner is the image of X in the top left one, so their moves are added up.
+	// The corner next to X is kept in one of its two symmetric images
+	moves := b.Moves(Grid{{1, 0, 0}, {0, 0, 0}, {0, 0, 0}})
+	want := map[Coord]int{{Row: 1, Col: 1}: 6, {Row: 2, Col: 0}: 1}
+	if len(moves) != len(want) {
+		t.Fatalf("Moves = %v, want %v", moves, want)
+	}
+	for _, m := range moves {
+		if want[m.Move] != m.Weight {
+			t.Errorf("move %v has weight %d, want %d", m.Move, m.Weight, want[m.Move])
+		}
+	}
+}
+
+func TestParseBookMalformed(t *testing.T) {
+	lines := []string{
+		"........ 1,1:1",
+		"....#.... 1,1:1",
+		"......... 1-1:1",
+		"......... 3,0:1",
+		"X........ 0,0:1",
+		"......... 1,1:-1",
+	}
+	for _, line := range lines {
+		if _, err := ParseBook(strings.NewReader(line)); err == nil {
+			t.Errorf("ParseBook(%q) accepted", line)
+		}
+	}
+}
+
+func TestBookWriteRoundTrip(t *testing.T) {
+	b, err := ParseBook(strings.NewReader(bookText))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := "......... 1,1:5 0,0:3\nX........ 1,1:6 2,0:1\n"
+	if buf.String() != want {
+		t.Errorf("Write = %q, want %q", buf.String(), want)
+	}
+
+	parsed, err := ParseBook(&buf)
+	if err != nil {
+		t.Fatalf("ParseBook of the written book: %v", err)
+	}
+	var again bytes.Buffer
+	parsed.Write(&again)
+	if again.String() != want {
+		t.Errorf("written again %q, want %q", again.String(), want)
+	}
+}
+
+func TestBuildBook(t *testing.T) {
+	win := NewGame()
+	for i, move := range []Coord{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}} {
+		win.Play(move, []Mark{Cross, Nought}[i%2])
+	}
+	win.State, win.Winner = Completed, Cross
+
+	draw := NewGame()
+	for i, move := range []Coord{{1, 1}, {0, 0}, {0, 1}, {2, 1}, {1, 0}, {1, 2}, {0, 2}, {2, 0}, {2, 2}} {
+		draw.Play(move, []Mark{Cross, Nought}[i%2])
+	}
+	draw.State = Completed
+
+	unfinished := NewGame()
+	unfinished.Play(Coord{Row: 2, Col: 2}, Cross)
+
+	b := BuildBook([]*Game{win, draw, unfinished}, 2)
+	tests := []struct {
+		grid Grid
+		want map[Coord]int
+	}{
+		// the first move of the winner weighs 2, of the drawn game 1
+		{grid: NewGrid(GridSize), want: map[Coord]int{{Row: 0, Col: 0}: 2, {Row: 1, Col: 1}: 1}},
+		// the reply of the drawn game weighs 1, the reply of the loser adds nothing
+		{grid: Grid{{0, 0, 0}, {0, 1, 0}, {0, 0, 0}}, want: map[Coord]int{{Row: 0, Col: 0}: 1}},
+		{grid: Grid{{1, 0, 0}, {0, 0, 0}, {0, 0, 0}}, want: map[Coord]int{}},
+	}
+
+	for _, tt := range tests {
+		moves := b.Moves(tt.grid)
+		if len(moves) != len(tt.want) {
+			t.Errorf("%v: moves %v, want %v", tt.grid, moves, tt.want)
+			continue
+		}
+		for _, m := range moves {
+			if w, ok := tt.want[m.Move]; !ok || w != m.Weight {
+				t.Errorf("%v: move %v has weight %d, want %v", tt.grid, m.Move, m.Weight, tt.want)
+			}
+		}
+	}
+}
+
+func TestNextMoveBook(t *testing.T) {
+	b, err := ParseBook(strings.NewReader("......... 0,1:9 1,1:1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame()
+
+	// an edge opening keeps the draw, so the heaviest book move is played
+	if move, err := g.NextMove(Cross, b, 1, nil); err != nil || move != (Coord{Row: 0, Col: 1}) {
+		t.Errorf("NextMove with the book = %v, %v, want the edge", move, err)
+	}
+	if move, _ := g.NextMove(Cross, nil, 1, nil); move != (Coord{Row: 0, Col: 0}) {
+		t.Errorf("NextMove without a book = %v, want the first optimal move", move)
+	}
+
+	// a book reply that loses the drawn position is skipped
+	g.Play(Coord{Row: 0, Col: 0}, Cross)
+	spoiling, err := ParseBook(strings.NewReader("X........ 0,1:9\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if move, _ := g.NextMove(Nought, spoiling, 1, nil); move != (Coord{Row: 1, Col: 1}) {
+		t.Errorf("NextMove with a losing book move = %v, want the centre", move)
+	}
+}
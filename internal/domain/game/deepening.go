@@ -0,0 +1,269 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// searchWinScore is the score of a won position in the depth-limited search.
+// It is larger than any value of evaluate, so a forced win always outweighs a good position
+const searchWinScore = 1_000_000_000
+
+// contextCheckInterval is the number of visited nodes between checks of the search context
+const contextCheckInterval = 1024
+
+// searcher keeps the state of one depth-limited alpha-beta search
+type searcher struct {
+	ctx     context.Context
+	g       *Game
+	nodes   int
+	aborted bool // search was stopped by the context
+	cutoff  bool // some position was evaluated by the heuristic instead of being searched to the end
+}
+
+// IterativeDeepening searches the position with alpha-beta pruning, increasing the depth limit by one ply
+// after each completed iteration until the whole game tree is searched or ctx is done.
+// Root moves are split across at most workers goroutines (GOMAXPROCS if workers is not positive);
+// the chosen move doesn't depend on the number of workers.
+// Returns the best move of the deepest completed iteration and its depth. If ctx is done before the first
+// iteration completes, returns the cell chosen by HeuristicMove with depth 0.
+// Returns an error if the game is over.
+func IterativeDeepening(ctx context.Context, g *Game, currentPlayer Mark, workers int) (Coord, int, error) {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return NoCoord, 0, fmt.Errorf("could not find a valid move")
+	}
+
+	sim := g.Clone()
+	bestCoord, bestDepth := NoCoord, 0
+	maxDepth := len(sim.EmptyCells())
+
+	for depth := 1; depth <= maxDepth; depth++ {
+		var coord Coord
+		var aborted, cutoff bool
+		if Workers(workers) == 1 {
+			s := searcher{ctx: ctx, g: sim}
+			_, coord = s.alphaBeta(currentPlayer, 0, depth, -searchWinScore-1, searchWinScore+1, bestCoord)
+			aborted, cutoff = s.aborted, s.cutoff
+		} else {
+			coord, aborted, cutoff = parallelAlphaBeta(ctx, sim, currentPlayer, depth, bestCoord, workers)
+		}
+		if aborted {
+			break
+		}
+
+		bestCoord, bestDepth = coord, depth
+		if !cutoff {
+			break
+		}
+	}
+
+	if bestCoord == NoCoord {
+		return HeuristicMove(g, currentPlayer), 0, nil
+	}
+	return bestCoord, bestDepth, nil
+}
+
+// alphaBeta searches the position to the depth limit and returns its score from the Cross point of view
+// and the best move. The first move is searched first: it is the best move of the previous iteration.
+func (s *searcher) alphaBeta(currentPlayer Mark, depth, limit, alpha, beta int, first Coord) (int, Coord) {
+	s.nodes++
+	if s.nodes%contextCheckInterval == 0 && s.ctx.Err() != nil {
+		s.aborted = true
+	}
+	if s.aborted {
+		return 0, NoCoord
+	}
+
+	moves := s.g.candidateMoves()
+	if len(moves) == 0 {
+		return 0, NoCoord
+	}
+	if depth == limit {
+		s.cutoff = true
+		return s.g.evaluate(), NoCoord
+	}
+
+	for i, c := range moves {
+		if c == first {
+			moves[0], moves[i] = moves[i], moves[0]
+			break
+		}
+	}
+
+	opponent := GetOpponent(currentPlayer)
+	bestScore := GetBestScore(currentPlayer)
+	bestCoord := NoCoord
+
+	for _, c := range moves {
+		s.g.Grid[c.Row][c.Col] = currentPlayer
+		var score int
+		if s.g.completesLine(c) {
+			score = searchWinPoints(s.g.lineWinner(currentPlayer), depth+1)
+		} else {
+			score, _ = s.alphaBeta(opponent, depth+1, limit, alpha, beta, NoCoord)
+		}
+		s.g.Grid[c.Row][c.Col] = Empty
+
+		if s.aborted {
+			return 0, NoCoord
+		}
+
+		if UpdateBestScore(currentPlayer, score, &bestScore) {
+			bestCoord = c
+		}
+		if currentPlayer == Cross {
+			alpha = max(alpha, bestScore)
+		} else {
+			beta = min(beta, bestScore)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+
+	return bestScore, bestCoord
+}
+
+// parallelAlphaBeta searches the first root move, which is the best move of the previous iteration,
+// and then the rest of the root moves on the goroutines of the pool with the window bounded by
+// the first move score. Returns the best move together with the aborted and cutoff flags of the whole search,
+// NoCoord if there is no move.
+func parallelAlphaBeta(ctx context.Context, g *Game, currentPlayer Mark, limit int, first Coord, workers int) (Coord, bool, bool) {
+	moves := g.candidateMoves()
+	if len(moves) == 0 {
+		return NoCoord, false, false
+	}
+	for i, c := range moves {
+		if c == first {
+			moves[0], moves[i] = moves[i], moves[0]
+			break
+		}
+	}
+
+	var aborted, cutoff atomic.Bool
+	opponent := GetOpponent(currentPlayer)
+	searchMove := func(sim *Game, c Coord, alpha, beta int) int {
+		sim.Grid[c.Row][c.Col] = currentPlayer
+		defer func() { sim.Grid[c.Row][c.Col] = Empty }()
+		if sim.completesLine(c) {
+			return searchWinPoints(sim.lineWinner(currentPlayer), 1)
+		}
+
+		s := searcher{ctx: ctx, g: sim}
+		score, _ := s.alphaBeta(opponent, 1, limit, alpha, beta, NoCoord)
+		if s.aborted {
+			aborted.Store(true)
+		}
+		if s.cutoff {
+			cutoff.Store(true)
+		}
+		return score
+	}
+
+	firstScore := searchMove(g, moves[0], -searchWinScore-1, searchWinScore+1)
+	alpha, beta := firstScore, searchWinScore+1
+	if currentPlayer == Nought {
+		alpha, beta = -searchWinScore-1, firstScore
+	}
+
+	scores := splitRoot(g, moves[1:], workers, func(sim *Game, c Coord) int {
+		return searchMove(sim, c, alpha, beta)
+	})
+
+	_, coord := pickBest(currentPlayer, moves, append([]int{firstScore}, scores...))
+	return coord, aborted.Load(), cutoff.Load()
+}
+
+// searchWinPoints returns the score of a win of the given mark reached at the given depth:
+// quicker wins are preferred, slower losses are preferred
+func searchWinPoints(winner Mark, depth int) int {
+	if winner == Cross {
+		return searchWinScore - depth
+	}
+	return depth - searchWinScore
+}
+
+// candidateMoves returns the empty cells worth searching: the ones next to a mark of a player,
+// or the central cell if there is no mark yet and it isn't blocked. All empty cells are searched
+// if there are no such cells, as when blocked cells wall off every mark, and under the misere rule,
+// where the far cells are the safe ones. Gravity games search all legal drops
+func (g *Game) candidateMoves() []Coord {
+	if g.Rules.Gravity {
+		return g.gravityMoves()
+	}
+	if g.Rules.Misere {
+		return g.EmptyCells()
+	}
+
+	var moves []Coord
+	occupied := false
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
+			if g.Grid[i][j].isPlayer() {
+				occupied = true
+			} else if g.Grid[i][j] == Empty && g.hasNeighbour(Coord{Row: i, Col: j}) {
+				moves = append(moves, Coord{Row: i, Col: j})
+			}
+		}
+	}
+
+	if !occupied && len(g.Grid) > 0 {
+		center := len(g.Grid) / 2
+		if g.Grid[center][center] == Empty {
+			return []Coord{{Row: center, Col: center}}
+		}
+	}
+	if len(moves) == 0 {
+		return g.EmptyCells()
+	}
+	return moves
+}
+
+// hasNeighbour reports whether any of the eight cells around the given one has a mark of a player
+func (g *Game) hasNeighbour(c Coord) bool {
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			n, ok := g.cell(Coord{Row: c.Row + dr, Col: c.Col + dc})
+			if (dr != 0 || dc != 0) && ok && g.Grid[n.Row][n.Col].isPlayer() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evaluate estimates a position without a winner from the Cross point of view.
+// Every window of Rules.WinLength cells that is still open for only one side adds
+// a value growing with the number of that side's marks in it, under the misere rule it is subtracted
+func (g *Game) evaluate() int {
+	score := 0
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
+			for _, d := range lineDirections {
+				crosses, noughts, ok := g.countWindow(Coord{Row: i, Col: j}, d, Cross)
+				if !ok {
+					continue
+				}
+				if noughts == 0 && crosses > 0 {
+					score += windowValue(crosses)
+				} else if crosses == 0 && noughts > 0 {
+					score -= windowValue(noughts)
+				}
+			}
+		}
+	}
+	if g.Rules.Misere {
+		return -score
+	}
+	return score
+}
+
+// windowValue returns 10 to the power of the number of marks in an open window
+func windowValue(marks int) int {
+	value := 1
+	for k := 0; k < marks; k++ {
+		value *= 10
+	}
+	return value
+}
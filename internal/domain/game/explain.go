@@ -0,0 +1,176 @@
+package game
+
+// Reason tells why a move was chosen
+type Reason int
+
+const (
+	ReasonWin        Reason = iota // The move completes a line
+	ReasonBlock                    // The move takes the cell where the opponent would complete a line
+	ReasonFork                     // The move creates two winning threats at once
+	ReasonForkBlock                // The move prevents a fork of the opponent
+	ReasonEvaluation               // The move is chosen by evaluation of the position
+)
+
+// exhaustiveCells is the largest number of empty cells a position is searched to the end for an explanation
+const exhaustiveCells = 9
+
+// variationLength is the number of moves of the continuation shown for positions that can't be searched to the end
+const variationLength = 4
+
+// String returns the name of the reason
+func (r Reason) String() string {
+	switch r {
+	case ReasonWin:
+		return "win"
+	case ReasonBlock:
+		return "block"
+	case ReasonFork:
+		return "fork"
+	case ReasonForkBlock:
+		return "forkBlock"
+	default:
+		return "evaluation"
+	}
+}
+
+// Explanation describes why a move was played
+type Explanation struct {
+	Move      Coord
+	Reason    Reason
+	Cells     []Coord // Winning cells of the threats behind the reason: the blocked, created or prevented ones
+	Score     int     // Evaluation of the position after the move from the Cross point of view
+	Solved    bool    // Score is exact: the position was searched to the end
+	Variation []Coord // Expected continuation starting with the move
+}
+
+// ExplainMove analyses the move of the current player in the position before the move by simple rules:
+// an immediate win, a block of the opponent's winning cell, a fork, a block of the opponent's fork,
+// and otherwise an evaluation with the principal variation. The game is not changed.
+// Moves of ultimate, gravity and wild games are only told apart as wins and other moves,
+// in wild games currentPlayer is the mark placed by the move,
+// under the misere rule threats are lines to avoid, so moves are explained by evaluation only.
+func ExplainMove(g *Game, move Coord, currentPlayer Mark) Explanation {
+	sim := g.Clone()
+	opponent := GetOpponent(currentPlayer)
+	e := Explanation{Move: move, Reason: ReasonEvaluation}
+
+	if g.Rules.Variant == Ultimate || g.Rules.Gravity || g.Rules.Wild {
+		mover := currentPlayer
+		if g.Rules.Wild {
+			mover = g.PlayerToMove()
+		}
+
+		sim.Play(move, currentPlayer)
+		if gameOver, winner := sim.IsOver(); gameOver && winner == mover {
+			e.Reason = ReasonWin
+		}
+		e.Variation = []Coord{move}
+		return e
+	}
+
+	if g.Rules.Misere {
+		sim.Play(move, currentPlayer)
+		e.Score, e.Solved, e.Variation = sim.principalVariation(opponent)
+		e.Variation = append([]Coord{move}, e.Variation...)
+		return e
+	}
+
+	threats := sim.winningCells(opponent)
+	forks := sim.forkCells(opponent)
+
+	sim.Play(move, currentPlayer)
+	e.Score, e.Solved, e.Variation = sim.principalVariation(opponent)
+	e.Variation = append([]Coord{move}, e.Variation...)
+
+	switch {
+	case sim.completesLine(move):
+		e.Reason = ReasonWin
+	case containsCoord(threats, move):
+		e.Reason, e.Cells = ReasonBlock, threats
+	case len(sim.winningCells(currentPlayer)) >= 2:
+		e.Reason, e.Cells = ReasonFork, sim.winningCells(currentPlayer)
+	case len(forks) > 0 && len(sim.forkCells(opponent)) < len(forks):
+		e.Reason, e.Cells = ReasonForkBlock, forks
+	}
+	return e
+}
+
+// principalVariation returns the score of the position from the Cross point of view and the expected
+// continuation with the current player to move. Positions with few empty cells are searched by Minimax
+// to the end, larger ones are continued by HeuristicMove and scored by evaluate. HeuristicMove doesn't
+// know the misere rule, so larger misere positions are only scored.
+func (g *Game) principalVariation(currentPlayer Mark) (int, bool, []Coord) {
+	sim := g.Clone()
+	solved := len(sim.EmptyCells()) <= exhaustiveCells
+	if gameOver, _ := sim.IsOver(); !solved && !gameOver && g.Rules.Misere {
+		return sim.evaluate(), false, nil
+	}
+
+	score := 0
+	if solved {
+		score, _ = Minimax(sim, currentPlayer, 0)
+	}
+
+	var variation []Coord
+	for player := currentPlayer; solved || len(variation) < variationLength; player = GetOpponent(player) {
+		if gameOver, _ := sim.IsOver(); gameOver {
+			break
+		}
+
+		var move Coord
+		if solved {
+			move = SearchBestMove(sim, player, 1)
+		} else {
+			move = HeuristicMove(sim, player)
+		}
+		sim.Play(move, player)
+		variation = append(variation, move)
+	}
+
+	if !solved {
+		if gameOver, winner := sim.IsOver(); gameOver {
+			if winner != Empty {
+				score = searchWinPoints(winner, len(variation))
+			}
+		} else {
+			score = sim.evaluate()
+		}
+	}
+	return score, solved, variation
+}
+
+// winningCells returns the empty cells where the player would complete a line
+func (g *Game) winningCells(player Mark) []Coord {
+	var cells []Coord
+	for _, c := range g.EmptyCells() {
+		g.Grid[c.Row][c.Col] = player
+		if g.completesLine(c) {
+			cells = append(cells, c)
+		}
+		g.Grid[c.Row][c.Col] = Empty
+	}
+	return cells
+}
+
+// forkCells returns the empty cells where a move of the player would create two winning cells at once
+func (g *Game) forkCells(player Mark) []Coord {
+	var cells []Coord
+	for _, c := range g.EmptyCells() {
+		g.Grid[c.Row][c.Col] = player
+		if !g.completesLine(c) && len(g.winningCells(player)) >= 2 {
+			cells = append(cells, c)
+		}
+		g.Grid[c.Row][c.Col] = Empty
+	}
+	return cells
+}
+
+// containsCoord reports whether the coordinate is among coords
+func containsCoord(coords []Coord, c Coord) bool {
+	for _, other := range coords {
+		if other == c {
+			return true
+		}
+	}
+	return false
+}
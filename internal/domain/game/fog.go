@@ -0,0 +1,157 @@
+package game
+
+import "fmt"
+
+// fogSamples is the number of guesses of the hidden marks FogMove votes over
+const fogSamples = 64
+
+// fogRetries is the number of times a guess of the hidden marks that completes a line of the opponent,
+// which can't happen in a game that is not over, is drawn again
+const fogRetries = 8
+
+// Hit is a move of a player in a fog game onto a hidden mark of the opponent.
+// The move is not made, the mark is revealed to the player, who moves again
+type Hit struct {
+	Player Mark
+	Cell   Coord
+}
+
+// View returns the grid as the player of a fog game sees it: their own marks and the marks
+// of the opponent they hit, the other marks of the opponent are empty cells.
+// Once the game is over, and in games without the fog rule, all marks are shown
+func (g *Game) View(player Mark) Grid {
+	view := g.Grid.Clone()
+	if gameOver, _ := g.IsOver(); gameOver || !g.Rules.Fog {
+		return view
+	}
+
+	opponent := GetOpponent(player)
+	for i := range view {
+		for j := range view[i] {
+			if view[i][j] == opponent && !g.revealed(player, Coord{Row: i, Col: j}) {
+				view[i][j] = Empty
+			}
+		}
+	}
+	return view
+}
+
+// HiddenMarks returns the number of marks of the opponent the player of a fog game doesn't see.
+// Both players know it: the marks of each player are counted by the moves made
+func (g *Game) HiddenMarks(player Mark) int {
+	opponent := GetOpponent(player)
+	marks := g.MoveCount() / 2
+	if opponent == Cross {
+		marks = (g.MoveCount() + 1) / 2
+	}
+
+	for _, hit := range g.Hits {
+		if hit.Player == player {
+			marks--
+		}
+	}
+	return marks
+}
+
+// Probe reports whether the move of the player in a fog game hits a hidden mark of the opponent.
+// A hit is recorded and reveals the mark to the player, the move is not made and the player moves again.
+// Otherwise the move is to be made by SetPlayerMove, which rejects the cells the player knows are occupied.
+// Returns an error if the game is over or the cell is not on the board
+func (g *Game) Probe(move Coord, player Mark) (bool, error) {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return false, fmt.Errorf("no move possible: game is over")
+	}
+	if !g.inside(move) {
+		return false, fmt.Errorf("no move possible: no such cell")
+	}
+
+	if !g.Rules.Fog || g.Grid[move.Row][move.Col] != GetOpponent(player) || g.revealed(player, move) {
+		return false, nil
+	}
+	g.Hits = append(g.Hits, Hit{Player: player, Cell: move})
+	return true, nil
+}
+
+// FogProbe makes the moves of the player in a fog game until one doesn't hit: choose picks a cell
+// from the view of the player, a hit reveals the mark and choose picks again with the new view.
+// Returns the cell that didn't hit or NoCoord if choose gives up
+func (g *Game) FogProbe(player Mark, choose func(view Grid) Coord) Coord {
+	for {
+		c := choose(g.View(player))
+		if c == NoCoord {
+			return NoCoord
+		}
+
+		hit, err := g.Probe(c, player)
+		if err != nil {
+			return NoCoord
+		}
+		if !hit {
+			return c
+		}
+	}
+}
+
+// FogMove returns the move of the player in a fog game chosen from the view of the player only.
+// The hidden marks of the opponent are guessed fogSamples times among the cells the player sees empty,
+// and the cell chosen by HeuristicMove for most guesses is tried. Hits are recorded by FogProbe.
+// Returns NoCoord if the game is over
+func FogMove(g *Game, player Mark, rnd RandomSource) Coord {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return NoCoord
+	}
+
+	return g.FogProbe(player, func(view Grid) Coord {
+		unknown := (&Game{Grid: view}).EmptyCells()
+		if len(unknown) == 0 {
+			return NoCoord
+		}
+
+		votes := map[Coord]int{}
+		hidden := g.HiddenMarks(player)
+		for i := 0; i < fogSamples; i++ {
+			guess := g.guessHidden(view, unknown, GetOpponent(player), hidden, rnd)
+			if c := HeuristicMove(guess, player); c != NoCoord {
+				votes[c]++
+			}
+		}
+
+		best := unknown[0]
+		for _, c := range unknown {
+			if votes[c] > votes[best] {
+				best = c
+			}
+		}
+		return best
+	})
+}
+
+// guessHidden returns a game with the view of the player and the given number of hidden marks
+// of the opponent placed in random unknown cells. Guesses that complete a line of the opponent are
+// drawn again up to fogRetries times
+func (g *Game) guessHidden(view Grid, unknown []Coord, opponent Mark, hidden int, rnd RandomSource) *Game {
+	cells := append([]Coord(nil), unknown...)
+	guess := &Game{Rules: g.Rules}
+	for retry := 0; ; retry++ {
+		guess.Grid = view.Clone()
+		for k := 0; k < hidden && k < len(cells); k++ {
+			swap := k + rnd.Intn(len(cells)-k)
+			cells[k], cells[swap] = cells[swap], cells[k]
+			guess.Grid[cells[k].Row][cells[k].Col] = opponent
+		}
+
+		if over, winner := guess.IsOver(); !over || winner != opponent || retry == fogRetries {
+			return guess
+		}
+	}
+}
+
+// revealed reports whether the player of a fog game hit the mark of the opponent in the cell
+func (g *Game) revealed(player Mark, c Coord) bool {
+	for _, hit := range g.Hits {
+		if hit.Player == player && hit.Cell == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package game
+
+import "testing"
+
+// fogGame returns a classic fog game after the moves, made by X and O in turn
+func fogGame(t *testing.T, moves ...Coord) *Game {
+	t.Helper()
+	rules := DefaultRules()
+	rules.Fog = true
+	g, err := NewGameWithRules(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, move := range moves {
+		player := Cross
+		if i%2 == 1 {
+			player = Nought
+		}
+		if err := g.SetPlayerMove(move, player); err != nil {
+			t.Fatalf("move %v: %v", move, err)
+		}
+	}
+	return g
+}
+
+func TestFogView(t *testing.T) {
+	g := fogGame(t, Coord{Row: 0, Col: 0}, Coord{Row: 1, Col: 1}, Coord{Row: 2, Col: 2}, Coord{Row: 0, Col: 2})
+
+	if got, want := g.View(Cross), (Grid{{1, 0, 0}, {0, 0, 0}, {0, 0, 1}}); !got.Equal(want) {
+		t.Errorf("view of X = %v, want %v", got, want)
+	}
+	if got, want := g.View(Nought), (Grid{{0, 0, 2}, {0, 2, 0}, {0, 0, 0}}); !got.Equal(want) {
+		t.Errorf("view of O = %v, want %v", got, want)
+	}
+	if g.HiddenMarks(Cross) != 2 || g.HiddenMarks(Nought) != 2 {
+		t.Errorf("hidden marks = %d, %d, want 2, 2", g.HiddenMarks(Cross), g.HiddenMarks(Nought))
+	}
+}
+
+func TestFogProbeHit(t *testing.T) {
+	g := fogGame(t, Coord{Row: 0, Col: 0}, Coord{Row: 1, Col: 1}, Coord{Row: 2, Col: 2}, Coord{Row: 0, Col: 2})
+	grid := g.Grid.Clone()
+
+	hit, err := g.Probe(Coord{Row: 1, Col: 1}, Cross)
+	if err != nil || !hit {
+		t.Fatalf("Probe = %v, %v, want a hit", hit, err)
+	}
+	if !g.Grid.Equal(grid) || g.MoveCount() != 4 || g.PlayerToMove() != Cross {
+		t.Errorf("hit made a move: grid %v after %d moves, %v to move", g.Grid, g.MoveCount(), g.PlayerToMove())
+	}
+	if got, want := g.View(Cross), (Grid{{1, 0, 0}, {0, 2, 0}, {0, 0, 1}}); !got.Equal(want) {
+		t.Errorf("view of X = %v, want %v", got, want)
+	}
+	if got, want := g.View(Nought), (Grid{{0, 0, 2}, {0, 2, 0}, {0, 0, 0}}); !got.Equal(want) {
+		t.Errorf("view of O = %v, want %v", got, want)
+	}
+	if g.HiddenMarks(Cross) != 1 || g.HiddenMarks(Nought) != 2 {
+		t.Errorf("hidden marks = %d, %d, want 1, 2", g.HiddenMarks(Cross), g.HiddenMarks(Nought))
+	}
+
+	if hit, err := g.Probe(Coord{Row: 1, Col: 1}, Cross); err != nil || hit {
+		t.Errorf("Probe of the revealed mark = %v, %v, want no hit", hit, err)
+	}
+	if len(g.Hits) != 1 {
+		t.Errorf("hits = %v, want one", g.Hits)
+	}
+	if err := g.SetPlayerMove(Coord{Row: 1, Col: 0}, Cross); err != nil {
+		t.Errorf("move after the hit: %v", err)
+	}
+}
+
+func TestFogProbeMiss(t *testing.T) {
+	g := fogGame(t, Coord{Row: 0, Col: 0}, Coord{Row: 1, Col: 1})
+
+	for _, c := range []Coord{{Row: 2, Col: 2}, {Row: 0, Col: 0}} {
+		if hit, err := g.Probe(c, Cross); err != nil || hit {
+			t.Errorf("Probe %v = %v, %v, want no hit", c, hit, err)
+		}
+	}
+	if _, err := g.Probe(Coord{Row: 3, Col: 0}, Cross); err == nil {
+		t.Error("Probe outside the board is accepted")
+	}
+	if len(g.Hits) != 0 || g.HiddenMarks(Cross) != 1 {
+		t.Errorf("hits = %v with %d hidden marks, want none with 1", g.Hits, g.HiddenMarks(Cross))
+	}
+
+	g.Rules.Fog = false
+	if hit, _ := g.Probe(Coord{Row: 1, Col: 1}, Cross); hit {
+		t.Error("Probe hits without the fog rule")
+	}
+	if got := g.View(Cross); !got.Equal(g.Grid) {
+		t.Errorf("view without the fog rule = %v, want %v", got, g.Grid)
+	}
+}
+
+func TestFogViewGameOver(t *testing.T) {
+	g := fogGame(t, Coord{Row: 0, Col: 0}, Coord{Row: 1, Col: 0}, Coord{Row: 0, Col: 1}, Coord{Row: 1, Col: 1}, Coord{Row: 0, Col: 2})
+
+	if got := g.View(Nought); !got.Equal(g.Grid) {
+		t.Errorf("view of O after the game = %v, want %v", got, g.Grid)
+	}
+	if _, err := g.Probe(Coord{Row: 2, Col: 2}, Nought); err == nil {
+		t.Error("Probe after the game is accepted")
+	}
+}
+
+func TestFogProbeChoosesAgain(t *testing.T) {
+	g := fogGame(t, Coord{Row: 0, Col: 0}, Coord{Row: 1, Col: 1})
+
+	var views []Grid
+	c := g.FogProbe(Cross, func(view Grid) Coord {
+		views = append(views, view)
+		if view[1][1] == Empty {
+			return Coord{Row: 1, Col: 1}
+		}
+		return Coord{Row: 2, Col: 2}
+	})
+
+	if c != (Coord{Row: 2, Col: 2}) || len(views) != 2 || views[1][1][1] != Nought {
+		t.Errorf("FogProbe = %v after views %v, want (2, 2) after the hit", c, views)
+	}
+	if g.HiddenMarks(Cross) != 0 {
+		t.Errorf("hidden marks = %d, want 0", g.HiddenMarks(Cross))
+	}
+}
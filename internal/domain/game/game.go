@@ -9,14 +9,21 @@ import (
 // GridSize - default numbers of rows and cols in TicTacToe game
 const GridSize = 3
 
+// MinGridSize and MaxGridSize - limits of rows and cols numbers for larger board variants
+const (
+	MinGridSize = 3
+	MaxGridSize = 10
+)
+
 // Mark represents a player's mark or state on the game board
 type Mark int
 
 // Constants representing the possible marks on the game board
 const (
-	Empty  Mark = iota // Empty cell on the board
-	Cross              // Player's mark: Cross (X)
-	Nought             // Player's mark: Nought (O)
+	Empty   Mark = iota // Empty cell on the board
+	Cross               // Player's mark: Cross (X)
+	Nought              // Player's mark: Nought (O)
+	Blocked             // Cell of a custom layout that neither player may use
 )
 
 // State represents the current status of the game
@@ -36,51 +43,347 @@ type Coord struct {
 // NoCoord represents an invalid or undefined coordinate
 var NoCoord = Coord{Row: -1, Col: -1}
 
-// Grid represents the game board as a 2D array of Marks
-type Grid [GridSize][GridSize]Mark
+// Grid represents the game board as a square 2D slice of Marks
+type Grid [][]Mark
+
+// NewGrid returns an empty grid with the given numbers of rows and cols
+func NewGrid(size int) Grid {
+	grid := make(Grid, size)
+	for i := range grid {
+		grid[i] = make([]Mark, size)
+	}
+	return grid
+}
+
+// Clone returns a copy of the grid that does not share cells with the original
+func (grid Grid) Clone() Grid {
+	clone := make(Grid, len(grid))
+	for i := range grid {
+		clone[i] = append([]Mark(nil), grid[i]...)
+	}
+	return clone
+}
+
+// Equal reports whether two grids have the same size and marks
+func (grid Grid) Equal(other Grid) bool {
+	if len(grid) != len(other) {
+		return false
+	}
+	for i := range grid {
+		if len(grid[i]) != len(other[i]) {
+			return false
+		}
+		for j := range grid[i] {
+			if grid[i][j] != other[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Variant is the rule set a game is played by
+type Variant int
+
+// Constants representing the supported rule sets
+const (
+	Classic  Variant = iota // Rules.WinLength same marks in a row on a square board
+	Ultimate                // 3x3 small boards, every move sends the opponent to the small board of the same position
+	Gomoku                  // Five in a row on the 15x15 board
+	Notakto                 // Crosses only on Rules.Boards 3x3 boards, completing a line kills the board, killing the last one loses
+	Morris                  // Three marks each on the 3x3 board, once placed they move to the next empty cell along a line
+	Qubic                   // Four in a row in the 4x4x4 cube, kept as four 4x4 layers
+)
+
+// variantNames are the names of the variants used by the API and in stored games
+var variantNames = map[Variant]string{
+	Classic:  "classic",
+	Ultimate: "ultimate",
+	Gomoku:   "gomoku",
+	Notakto:  "notakto",
+	Morris:   "morris",
+	Qubic:    "qubic",
+}
+
+// String returns the name of the variant
+func (v Variant) String() string {
+	return variantNames[v]
+}
+
+// Variants returns all supported variants
+func Variants() []Variant {
+	return []Variant{Classic, Ultimate, Gomoku, Notakto, Morris, Qubic}
+}
+
+// Rules returns the default rules of the variant
+func (v Variant) Rules() Rules {
+	switch v {
+	case Ultimate:
+		return Rules{Size: UltimateSize, WinLength: GridSize, Variant: Ultimate}
+	case Gomoku:
+		return Rules{Size: GomokuSize, WinLength: GomokuWinLength, Variant: Gomoku}
+	case Notakto:
+		return Rules{Size: GridSize, WinLength: GridSize, Variant: Notakto, Boards: NotaktoBoards}
+	case Morris:
+		return Rules{Size: GridSize, WinLength: GridSize, Variant: Morris}
+	case Qubic:
+		return Rules{Size: QubicSize, WinLength: QubicSize, Variant: Qubic}
+	}
+	return DefaultRules()
+}
+
+// ParseVariant returns the variant with the given name, an empty name selects Classic.
+// Returns an error if there is no such variant
+func ParseVariant(name string) (Variant, error) {
+	if name == "" {
+		return Classic, nil
+	}
+	for v, n := range variantNames {
+		if n == name {
+			return v, nil
+		}
+	}
+	return Classic, fmt.Errorf("unknown variant %q", name)
+}
+
+// Rules describes the board and the winning condition chosen at game creation
+type Rules struct {
+	Size      int     // Number of rows and cols
+	WinLength int     // Number of same marks in a row needed to win
+	Variant   Variant // Rule set of the game
+	ExactFive bool    // Gomoku only: a line longer than five doesn't win
+	Misere    bool    // Classic only: completing a line loses instead of wins
+	Gravity   bool    // Classic only: a mark falls to the lowest empty cell of its column
+	Wild      bool    // Classic only: both players place either mark, the one who completes a line wins
+	Torus     bool    // Classic only: lines wrap around the edges of the board
+	Custom    bool    // Classic only: the game started from Game.Start instead of the empty board
+	Fog       bool    // Classic only: each player sees only their own marks and the ones they hit
+	Boards    int     // Notakto only: number of boards
+}
+
+// DefaultRules returns the rules of the classic 3x3 game
+func DefaultRules() Rules {
+	return Rules{Size: GridSize, WinLength: GridSize}
+}
+
+// IsStandard reports whether the rules are the classic 3x3 game
+func (r Rules) IsStandard() bool {
+	return r == DefaultRules()
+}
+
+// Validate checks that the variant, its options, board size and win length are supported
+func (r Rules) Validate() error {
+	if r.ExactFive && r.Variant != Gomoku {
+		return fmt.Errorf("exact five rule is only supported by gomoku")
+	}
+	if r.Misere && r.Variant != Classic {
+		return fmt.Errorf("misere rule is only supported by the classic variant")
+	}
+	if r.Gravity && r.Variant != Classic {
+		return fmt.Errorf("gravity rule is only supported by the classic variant")
+	}
+	if r.Wild && (r.Variant != Classic || r.Gravity) {
+		return fmt.Errorf("wild rule is only supported by the classic variant without gravity")
+	}
+
+	if r.Custom && (r.Variant != Classic || r.Gravity || r.Wild) {
+		return fmt.Errorf("custom layouts are only supported by the classic variant without the gravity and wild rules")
+	}
+	if r.Fog && (r.Variant != Classic || r.Misere || r.Gravity || r.Wild || r.Custom) {
+		return fmt.Errorf("fog rule is only supported by the classic variant without the misere, gravity and wild rules and custom layouts")
+	}
+	if r.Torus && r.Variant != Classic {
+		return fmt.Errorf("torus rule is only supported by the classic variant")
+	}
+	if r.Boards != 0 && r.Variant != Notakto {
+		return fmt.Errorf("boards are only supported by notakto")
+	}
+
+	switch r.Variant {
+	case Classic:
+	case Ultimate:
+		if r.Size != UltimateSize || r.WinLength != GridSize {
+			return fmt.Errorf("ultimate board must be %dx%d with win length %d", UltimateSize, UltimateSize, GridSize)
+		}
+		return nil
+	case Gomoku:
+		if r.Size != GomokuSize || r.WinLength != GomokuWinLength {
+			return fmt.Errorf("gomoku board must be %dx%d with win length %d", GomokuSize, GomokuSize, GomokuWinLength)
+		}
+		return nil
+	case Notakto:
+		if r.Size != GridSize || r.WinLength != GridSize {
+			return fmt.Errorf("notakto boards must be %dx%d with win length %d", GridSize, GridSize, GridSize)
+		}
+		if r.Boards < 1 || r.Boards > MaxNotaktoBoards {
+			return fmt.Errorf("number of boards must be between 1 and %d", MaxNotaktoBoards)
+		}
+		return nil
+	case Morris:
+		if r.Size != GridSize || r.WinLength != GridSize {
+			return fmt.Errorf("morris board must be %dx%d with win length %d", GridSize, GridSize, GridSize)
+		}
+		return nil
+	case Qubic:
+		if r.Size != QubicSize || r.WinLength != QubicSize {
+			return fmt.Errorf("qubic cube must be %dx%dx%d with win length %d", QubicSize, QubicSize, QubicSize, QubicSize)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown variant")
+	}
+
+	if r.Size < MinGridSize || r.Size > MaxGridSize {
+		return fmt.Errorf("board size must be between %d and %d", MinGridSize, MaxGridSize)
+	}
+	if r.WinLength < MinGridSize || r.WinLength > r.Size {
+		return fmt.Errorf("win length must be between %d and board size", MinGridSize)
+	}
+	return nil
+}
 
 // Game represents data about specified TicTacToe game instance
 type Game struct {
-	Grid   Grid      // Current state of the board
+	Grid   Grid      // Current state of the board, empty in multi-board games
 	ID     uuid.UUID // Unique identifier of the game
 	State  State     // Current state of the game
 	Winner Mark      // The winner mark
+	Rules  Rules     // Board size and winning condition
+	Engine string    // Name of the computer opponent strategy, empty for the default one
+	Moves  []Coord   // Moves made in the game in order, the first one by Cross
+
+	Boards     []Grid       // Boards of notakto games, layers of qubic games
+	BoardMoves []BoardCoord // Moves made in multi-board games in order
+	Steps      []Step       // Moves made in morris games in order
+
+	Start Grid  // Starting layout of custom games, nil for games started from the empty board
+	Hits  []Hit // Hidden marks of the opponent the players tried to play on in fog games, in order
 }
 
 // NewGame returns a new Game instance with initialized values
 func NewGame() *Game {
 	return &Game{
 		ID:     uuid.New(),
-		Grid:   Grid{},
+		Grid:   NewGrid(GridSize),
 		State:  InProgress,
 		Winner: Empty,
+		Rules:  DefaultRules(),
 	}
 }
 
-// IsOver checks if the game is finished (there is a horizontal, vertical or diagonal row of same symbols),
-// returns the finish status and the winner if there is one.
-// If there is no winner (a draw or the game is not finished yet), returns Empty mark
-func (g *Game) IsOver() (bool, Mark) {
-	for i := 0; i < GridSize; i++ {
-		if g.Grid[i][0] != Empty && g.Grid[i][0] == g.Grid[i][1] && g.Grid[i][1] == g.Grid[i][2] {
-			return true, g.Grid[i][0]
-		}
+// NewGameWithRules returns a new Game instance with the given rules.
+// Returns an error if the rules are not supported
+func NewGameWithRules(rules Rules) (*Game, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := NewGame()
+	g.Grid = NewGrid(rules.Size)
+	g.Rules = rules
+	switch rules.Variant {
+	case Notakto:
+		g.Grid, g.Boards = Grid{}, newBoards(rules.Boards, rules.Size)
+	case Qubic:
+		g.Grid, g.Boards = Grid{}, newBoards(rules.Size, rules.Size)
+	}
+	return g, nil
+}
+
+// Clone returns a copy of the game that can be changed without affecting the original
+func (g *Game) Clone() *Game {
+	clone := *g
+	clone.Grid = g.Grid.Clone()
+	if g.Start != nil {
+		clone.Start = g.Start.Clone()
+	}
+	clone.Moves = append([]Coord(nil), g.Moves...)
+	clone.BoardMoves = append([]BoardCoord(nil), g.BoardMoves...)
+	clone.Steps = append([]Step(nil), g.Steps...)
+	clone.Hits = append([]Hit(nil), g.Hits...)
+	clone.Boards = nil
+	for _, board := range g.Boards {
+		clone.Boards = append(clone.Boards, board.Clone())
+	}
+	return &clone
+}
+
+// Play sets the mark of the current player to the cell and records the move without any checks
+func (g *Game) Play(move Coord, currentPlayer Mark) {
+	g.Grid[move.Row][move.Col] = currentPlayer
+	g.Moves = append(g.Moves, move)
+}
+
+// LegalMoves returns the cells the next move can be made in: the empty cells in row-major order,
+// restricted by the rules of the variant. Gravity games list the lowest empty cell of every column
+func (g *Game) LegalMoves() []Coord {
+	if g.Rules.Variant == Ultimate {
+		return g.ultimateMoves()
+	}
+	if g.Rules.Gravity {
+		return g.gravityMoves()
+	}
+	return g.EmptyCells()
+}
 
-		if g.Grid[0][i] != Empty && g.Grid[0][i] == g.Grid[1][i] && g.Grid[1][i] == g.Grid[2][i] {
-			return true, g.Grid[0][i]
+// wins reports whether the move just made in the given cell wins the game.
+// It only looks at the lines through that cell, so it is cheap to call after every move
+func (g *Game) wins(c Coord) bool {
+	if g.Rules.Variant == Ultimate {
+		return g.ultimateWins(c)
+	}
+	return g.completesLine(c)
+}
+
+// EmptyCells returns coordinates of all empty cells in row-major order
+func (g *Game) EmptyCells() []Coord {
+	var cells []Coord
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
+			if g.Grid[i][j] == Empty {
+				cells = append(cells, Coord{Row: i, Col: j})
+			}
 		}
 	}
+	return cells
+}
 
-	if g.Grid[0][0] != Empty && g.Grid[0][0] == g.Grid[1][1] && g.Grid[1][1] == g.Grid[2][2] {
-		return true, g.Grid[0][0]
+// lineDirections are the row and col steps of horizontal, vertical and both diagonal lines
+var lineDirections = [4]Coord{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// IsOver checks if the game is finished (there is a horizontal, vertical or diagonal row
+// of Rules.WinLength same symbols, wrapping around the edges of a torus board),
+// returns the finish status and the winner if there is one.
+// If there is no winner (a draw or the game is not finished yet), returns Empty mark.
+// In wild games the player who completed the line wins, under the misere rule they lose.
+// Ultimate games are decided by the rows of won small boards, gomoku games by the lines through the last move.
+// Notakto games are over when all boards are dead, the winner is the player who didn't kill the last one.
+// Morris games also end when the player to move is blocked and loses, or by repetition in a draw.
+// Qubic games are decided by the lines of the cube
+func (g *Game) IsOver() (bool, Mark) {
+	switch g.Rules.Variant {
+	case Qubic:
+		return g.qubicOver()
+	case Notakto:
+		return g.notaktoOver()
+	case Morris:
+		return g.morrisOver()
+	case Ultimate:
+		return g.ultimateOver()
+	case Gomoku:
+		return g.gomokuOver()
 	}
 
-	if g.Grid[0][2] != Empty && g.Grid[0][2] == g.Grid[1][1] && g.Grid[1][1] == g.Grid[2][0] {
-		return true, g.Grid[0][2]
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
+			if g.Grid[i][j].isPlayer() && g.lineFrom(Coord{Row: i, Col: j}) {
+				return true, g.lineWinner(g.Grid[i][j])
+			}
+		}
 	}
 
-	for i := 0; i < GridSize; i++ {
-		for j := 0; j < GridSize; j++ {
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
 			if g.Grid[i][j] == Empty {
 				return false, Empty
 			}
@@ -90,16 +393,113 @@ func (g *Game) IsOver() (bool, Mark) {
 	return true, Empty
 }
 
-// NextMove returns next move from computer, calculated by minimax algorithm,
-// and error if next move is not possible
-func (g *Game) NextMove(currentPlayer Mark) (Coord, error) {
-	_, bestCoord := Minimax(g, currentPlayer, 0)
+// lineWinner returns the winner of the game where the mark completed a line: the mark itself,
+// in wild games the player who made the last move, or their opponent under the misere rule
+func (g *Game) lineWinner(mark Mark) Mark {
+	if g.Rules.Wild {
+		mark = g.lastMover()
+	}
+	if g.Rules.Misere {
+		return GetOpponent(mark)
+	}
+	return mark
+}
 
-	if bestCoord.Row == NoCoord.Row || bestCoord.Col == NoCoord.Col {
+// lineFrom reports whether a winning line of the mark in the given cell starts there
+func (g *Game) lineFrom(start Coord) bool {
+	mark := g.Grid[start.Row][start.Col]
+	for _, d := range lineDirections {
+		length := 1
+		for length < g.Rules.WinLength {
+			c, ok := g.cell(Coord{Row: start.Row + d.Row*length, Col: start.Col + d.Col*length})
+			if !ok || g.Grid[c.Row][c.Col] != mark {
+				break
+			}
+			length++
+		}
+		if length == g.Rules.WinLength {
+			return true
+		}
+	}
+	return false
+}
+
+// completesLine reports whether the mark in the given cell is part of a winning line,
+// with the exact five rule a line longer than Rules.WinLength doesn't count.
+// It only scans the lines through that cell, so it is cheap to call after every move
+func (g *Game) completesLine(c Coord) bool {
+	mark := g.Grid[c.Row][c.Col]
+	for _, d := range lineDirections {
+		length, _ := g.run(c, d, mark)
+		if length == g.Rules.WinLength || (length > g.Rules.WinLength && !g.Rules.ExactFive) {
+			return true
+		}
+	}
+	return false
+}
+
+// run returns the number of the marks in a row through the given cell in the direction, counting the cell,
+// and the number of its ends that are empty cells. On a torus board the row is at most as long as the board
+func (g *Game) run(c, d Coord, mark Mark) (int, int) {
+	length, open := 1, 0
+	for _, sign := range [2]int{1, -1} {
+		next := Coord{Row: c.Row + sign*d.Row, Col: c.Col + sign*d.Col}
+		for length < len(g.Grid) {
+			cell, ok := g.cell(next)
+			if !ok {
+				break
+			}
+			if g.Grid[cell.Row][cell.Col] != mark {
+				if g.Grid[cell.Row][cell.Col] == Empty {
+					open++
+				}
+				break
+			}
+			length++
+			next = Coord{Row: next.Row + sign*d.Row, Col: next.Col + sign*d.Col}
+		}
+	}
+	return length, open
+}
+
+// inside reports whether the coordinate is on the board
+func (g *Game) inside(c Coord) bool {
+	return c.Row >= 0 && c.Row < len(g.Grid) && c.Col >= 0 && c.Col < len(g.Grid)
+}
+
+// cell returns the cell of the board the coordinate stands for: on a torus board coordinates past an edge
+// wrap around to the opposite one. The second value is false if the coordinate is off a flat board
+func (g *Game) cell(c Coord) (Coord, bool) {
+	if !g.Rules.Torus {
+		return c, g.inside(c)
+	}
+	size := len(g.Grid)
+	return Coord{Row: (c.Row%size + size) % size, Col: (c.Col%size + size) % size}, true
+}
+
+// NextMove returns next move from computer, taken from the opening book if it is not nil, from the optimal
+// moves of the precomputed tablebase or calculated by minimax algorithm on at most workers goroutines
+// for positions the table does not cover, and error if next move is not possible.
+// With a nil rnd the move is always the same: the heaviest book move or the first optimal one,
+// otherwise rnd chooses among the book moves by weight or among all optimal moves
+func (g *Game) NextMove(currentPlayer Mark, book *Book, workers int, rnd RandomSource) (Coord, error) {
+	if coord, ok := book.choose(g, currentPlayer, rnd); ok {
+		return coord, nil
+	}
+
+	moves, _, ok := TablebaseMoves(g, currentPlayer)
+	if !ok {
+		moves = SearchBestMoves(g, currentPlayer, workers)
+	}
+
+	if len(moves) == 0 {
 		return NoCoord, fmt.Errorf("could not find a valid move")
 	}
 
-	return bestCoord, nil
+	if rnd == nil {
+		return moves[0], nil
+	}
+	return moves[rnd.Intn(len(moves))], nil
 }
 
 // SetPlayerMove checks player move coord and set it to game
@@ -111,14 +511,29 @@ func (g *Game) SetPlayerMove(move Coord, currentPlayer Mark) error {
 		return fmt.Errorf("no move possible: game is over")
 	}
 
-	if (move.Row < 0 || move.Row >= GridSize) || (move.Col < 0 || move.Col >= GridSize) {
+	if g.Rules.Variant.MultiBoard() {
+		return fmt.Errorf("no move possible: the move has to choose a board")
+	}
+	if g.Rules.Variant == Morris {
+		return fmt.Errorf("no move possible: morris moves are steps")
+	}
+
+	if !g.inside(move) {
 		return fmt.Errorf("no move possible: no such cell")
 	}
 
+	if g.Grid[move.Row][move.Col] == Blocked {
+		return fmt.Errorf("no move possible: cell is blocked")
+	}
+
 	if g.Grid[move.Row][move.Col] != Empty {
 		return fmt.Errorf("no move possible: cell is occupied")
 	}
 
-	g.Grid[move.Row][move.Col] = currentPlayer
+	if !containsCoord(g.LegalMoves(), move) {
+		return fmt.Errorf("no move possible: cell is not playable by the rules")
+	}
+
+	g.Play(move, currentPlayer)
 	return nil
 }
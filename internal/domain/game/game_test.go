@@ -0,0 +1,71 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+// playNextMoves plays a game of NextMove for both sides and returns its moves
+func playNextMoves(t *testing.T, rnd RandomSource) []Coord {
+	t.Helper()
+	g := NewGame()
+	var moves []Coord
+	for player := Cross; ; player = GetOpponent(player) {
+		if gameOver, _ := g.IsOver(); gameOver {
+			return moves
+		}
+
+		move, err := g.NextMove(player, nil, 1, rnd)
+		if err != nil {
+			t.Fatalf("NextMove after %v: %v", moves, err)
+		}
+		if err := g.SetPlayerMove(move, player); err != nil {
+			t.Fatalf("move %v after %v: %v", move, moves, err)
+		}
+		moves = append(moves, move)
+	}
+}
+
+func TestNextMoveDeterministic(t *testing.T) {
+	g := NewGame()
+	for player := Cross; ; player = GetOpponent(player) {
+		if gameOver, _ := g.IsOver(); gameOver {
+			break
+		}
+
+		want, _, _ := LookupTablebase(g, player)
+		move, err := g.NextMove(player, nil, 1, nil)
+		if err != nil || move != want {
+			t.Fatalf("NextMove = %v, %v, want %v", move, err, want)
+		}
+		g.Play(move, player)
+	}
+
+	if _, winner := g.IsOver(); winner != Empty {
+		t.Errorf("perfect play won by %v", winner)
+	}
+}
+
+func TestNextMoveSeed(t *testing.T) {
+	for _, seed := range []int64{1, 2, 42} {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			want := playNextMoves(t, NewRandomSource(seed))
+			for i := 0; i < 3; i++ {
+				if got := playNextMoves(t, NewRandomSource(seed)); !coordsEqual(got, want) {
+					t.Fatalf("game %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNextMoveUnseeded(t *testing.T) {
+	games := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		games[fmt.Sprint(playNextMoves(t, NewRandomSource(0)))] = true
+	}
+
+	if len(games) < 2 {
+		t.Errorf("20 unseeded games are all the same: %v", games)
+	}
+}
@@ -0,0 +1,90 @@
+package game
+
+import "math"
+
+// GomokuSize and GomokuWinLength - the board and the winning line of gomoku
+const (
+	GomokuSize      = 15
+	GomokuWinLength = 5
+)
+
+// Values of the threats a move makes in one direction, see threatValue
+const (
+	threatWin       = 1e9 // The move completes a winning line
+	threatOpenFour  = 1e7 // One move to win at either end: can't be blocked any more
+	threatFour      = 1e5 // One move to win at one end, or two moves to win with both ends open
+	threatThree     = 1e3 // Two moves to win at one end
+	defenceFraction = 0.9 // Share of the value of the opponent's threat a move blocks
+)
+
+// gomokuOver checks if a gomoku game is finished. Only the lines through the last move are scanned:
+// an earlier win would have finished the game. The game is drawn when all cells are taken
+func (g *Game) gomokuOver() (bool, Mark) {
+	if len(g.Moves) == 0 {
+		return false, Empty
+	}
+
+	last := g.Moves[len(g.Moves)-1]
+	if g.Grid[last.Row][last.Col] != Empty && g.completesLine(last) {
+		return true, g.Grid[last.Row][last.Col]
+	}
+	return len(g.Moves) >= len(g.Grid)*len(g.Grid), Empty
+}
+
+// ThreatMove returns a move for the current player chosen by the threats it makes and blocks without search:
+// every cell next to the marks is scored by the lines it builds for the player and spoils for the opponent,
+// so a win comes first, then blocking a win, an open four, blocking an open three and so on.
+// Returns NoCoord if there is no move.
+func ThreatMove(g *Game, currentPlayer Mark) Coord {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return NoCoord
+	}
+
+	opponent := GetOpponent(currentPlayer)
+	bestCoord := NoCoord
+	bestScore := math.Inf(-1)
+	for _, c := range g.candidateMoves() {
+		score := g.threatScore(c, currentPlayer) + defenceFraction*g.threatScore(c, opponent)
+		if score > bestScore {
+			bestCoord, bestScore = c, score
+		}
+	}
+	return bestCoord
+}
+
+// threatScore sums the values of the lines the player would build in all directions by a move to the empty cell
+func (g *Game) threatScore(c Coord, player Mark) float64 {
+	g.Grid[c.Row][c.Col] = player
+	defer func() { g.Grid[c.Row][c.Col] = Empty }()
+
+	score := 0.0
+	for _, d := range lineDirections {
+		score += g.threatValue(g.run(c, d, player))
+	}
+	return score
+}
+
+// threatValue values a line of length same marks with open empty ends:
+// the fewer moves it needs to win and the more open ends it has, the higher the value
+func (g *Game) threatValue(length, open int) float64 {
+	switch {
+	case length == g.Rules.WinLength || (length > g.Rules.WinLength && !g.Rules.ExactFive):
+		return threatWin
+	case length > g.Rules.WinLength || open == 0:
+		return 0
+	}
+
+	switch g.Rules.WinLength - length {
+	case 1:
+		if open == 2 {
+			return threatOpenFour
+		}
+		return threatFour
+	case 2:
+		if open == 2 {
+			return threatFour
+		}
+		return threatThree
+	}
+	return math.Pow(10, float64(length)) * float64(open)
+}
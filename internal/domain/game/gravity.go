@@ -0,0 +1,69 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// GravitySize and GravityWinLength - the default board and winning line of gravity games
+const (
+	GravitySize      = 7
+	GravityWinLength = 4
+)
+
+// Drop returns the cell a mark dropped into the column of a gravity game lands in: the lowest empty one.
+// Returns an error if there is no such column or it is full
+func (g *Game) Drop(col int) (Coord, error) {
+	if col < 0 || col >= len(g.Grid) {
+		return NoCoord, fmt.Errorf("no move possible: no such column")
+	}
+
+	row := g.dropRow(col)
+	if row < 0 {
+		return NoCoord, fmt.Errorf("no move possible: column is full")
+	}
+	return Coord{Row: row, Col: col}, nil
+}
+
+// dropRow returns the lowest empty row of the column, or -1 if the column is full
+func (g *Game) dropRow(col int) int {
+	for row := len(g.Grid) - 1; row >= 0; row-- {
+		if g.Grid[row][col] == Empty {
+			return row
+		}
+	}
+	return -1
+}
+
+// gravityMoves returns the lowest empty cell of every column that is not full, column by column
+func (g *Game) gravityMoves() []Coord {
+	var moves []Coord
+	for col := range g.Grid {
+		if row := g.dropRow(col); row >= 0 {
+			moves = append(moves, Coord{Row: row, Col: col})
+		}
+	}
+	return moves
+}
+
+// gravityPlayout drops random marks starting with the current player until the game ends
+// and returns the winner, or Empty for a draw
+func (g *Game) gravityPlayout(currentPlayer Mark, rnd *rand.Rand) Mark {
+	moves := g.gravityMoves()
+	for len(moves) > 0 {
+		k := rnd.Intn(len(moves))
+		c := moves[k]
+		g.Grid[c.Row][c.Col] = currentPlayer
+		if g.completesLine(c) {
+			return g.lineWinner(currentPlayer)
+		}
+
+		if c.Row > 0 && g.Grid[c.Row-1][c.Col] == Empty {
+			moves[k] = Coord{Row: c.Row - 1, Col: c.Col}
+		} else {
+			moves = append(moves[:k], moves[k+1:]...)
+		}
+		currentPlayer = GetOpponent(currentPlayer)
+	}
+	return Empty
+}
@@ -0,0 +1,84 @@
+package game
+
+import "math"
+
+// HeuristicMove returns a move for the current player chosen by simple rules without search:
+// win if possible, otherwise block the opponent's winning move, otherwise play the cell
+// with the best line potential. Only legal moves are considered. Returns NoCoord if there is no move.
+func HeuristicMove(g *Game, currentPlayer Mark) Coord {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return NoCoord
+	}
+
+	opponent := GetOpponent(currentPlayer)
+	cells := g.LegalMoves()
+
+	for _, player := range [2]Mark{currentPlayer, opponent} {
+		for _, c := range cells {
+			g.Grid[c.Row][c.Col] = player
+			wins := g.completesLine(c)
+			g.Grid[c.Row][c.Col] = Empty
+			if wins {
+				return c
+			}
+		}
+	}
+
+	bestCoord := NoCoord
+	bestScore := math.Inf(-1)
+	for _, c := range cells {
+		if score := g.cellPotential(c, currentPlayer); score > bestScore {
+			bestCoord, bestScore = c, score
+		}
+	}
+	return bestCoord
+}
+
+// cellPotential scores an empty cell by all windows of Rules.WinLength cells through it.
+// A window still open for one side adds a value growing with the number of that side's marks in it,
+// own windows are valued a bit higher than the opponent's ones that the move would spoil
+func (g *Game) cellPotential(c Coord, currentPlayer Mark) float64 {
+	score := 0.0
+	for _, d := range lineDirections {
+		for offset := 0; offset < g.Rules.WinLength; offset++ {
+			start := Coord{Row: c.Row - d.Row*offset, Col: c.Col - d.Col*offset}
+			own, opponent, ok := g.countWindow(start, d, currentPlayer)
+			if !ok {
+				continue
+			}
+
+			if opponent == 0 {
+				score += math.Pow(10, float64(own))
+			}
+			if own == 0 {
+				score += 0.9 * math.Pow(10, float64(opponent))
+			}
+		}
+	}
+	return score
+}
+
+// countWindow counts marks of the current player and the opponent in the window of
+// Rules.WinLength cells that starts at the given cell and goes in the given direction.
+// The last value is false if the window doesn't fit on the board or has a blocked cell that no line
+// can pass, windows wrap around a torus board
+func (g *Game) countWindow(start, d Coord, currentPlayer Mark) (int, int, bool) {
+	own, opponent := 0, 0
+	for k := 0; k < g.Rules.WinLength; k++ {
+		c, ok := g.cell(Coord{Row: start.Row + d.Row*k, Col: start.Col + d.Col*k})
+		if !ok {
+			return 0, 0, false
+		}
+
+		switch g.Grid[c.Row][c.Col] {
+		case Empty:
+		case Blocked:
+			return 0, 0, false
+		case currentPlayer:
+			own++
+		default:
+			opponent++
+		}
+	}
+	return own, opponent, true
+}
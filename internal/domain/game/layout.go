@@ -0,0 +1,58 @@
+package game
+
+import "fmt"
+
+// NewGameFromLayout returns a new classic game that starts from the given layout instead of the empty board.
+// The layout may contain blocked cells that neither player may use. It must be a square grid of the rules size
+// with as many crosses as noughts, as Cross moves first, without a winner and with an empty cell left.
+// Returns an error if the rules or the layout are not supported
+func NewGameFromLayout(rules Rules, layout Grid) (*Game, error) {
+	rules.Custom = true
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateLayout(layout, rules.Size); err != nil {
+		return nil, err
+	}
+
+	g := NewGame()
+	g.Rules = rules
+	g.Grid = layout.Clone()
+	g.Start = layout.Clone()
+
+	if over, _ := g.IsOver(); over {
+		return nil, fmt.Errorf("layout is already over")
+	}
+	return g, nil
+}
+
+// validateLayout checks that the layout is a square grid of the size with known marks
+// and as many crosses as noughts
+func validateLayout(layout Grid, size int) error {
+	if len(layout) != size {
+		return fmt.Errorf("layout must be %dx%d", size, size)
+	}
+
+	crosses, noughts := 0, 0
+	for i := range layout {
+		if len(layout[i]) != size {
+			return fmt.Errorf("layout must be %dx%d", size, size)
+		}
+		for j := range layout[i] {
+			switch layout[i][j] {
+			case Empty, Blocked:
+			case Cross:
+				crosses++
+			case Nought:
+				noughts++
+			default:
+				return fmt.Errorf("layout has an unknown mark in cell %d,%d", i, j)
+			}
+		}
+	}
+
+	if crosses != noughts {
+		return fmt.Errorf("layout must have as many crosses as noughts, Cross moves first")
+	}
+	return nil
+}
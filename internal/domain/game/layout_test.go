@@ -0,0 +1,62 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// walledLayout has every mark walled off by blocked cells once X plays the corner (3,3)
+var walledLayout = Grid{
+	{1, 2, 3, 0},
+	{3, 3, 3, 0},
+	{0, 0, 3, 3},
+	{0, 0, 3, 0},
+}
+
+// walledGame returns the game of walledLayout after the move of X to (3,3)
+func walledGame(t *testing.T) *Game {
+	t.Helper()
+	g, err := NewGameFromLayout(Rules{Size: 4, WinLength: 3, Variant: Classic, Custom: true}, walledLayout)
+	if err != nil {
+		t.Fatalf("NewGameFromLayout: %v", err)
+	}
+	if err := g.SetPlayerMove(Coord{Row: 3, Col: 3}, Cross); err != nil {
+		t.Fatalf("SetPlayerMove: %v", err)
+	}
+	return g
+}
+
+func TestCandidateMovesWalledOff(t *testing.T) {
+	g := walledGame(t)
+	if moves, want := g.candidateMoves(), g.EmptyCells(); !coordsEqual(moves, want) {
+		t.Errorf("candidateMoves = %v, want %v", moves, want)
+	}
+}
+
+func TestIterativeDeepeningWalledOff(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			g := walledGame(t)
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			move, _, err := IterativeDeepening(ctx, g, Nought, workers)
+			if err != nil {
+				t.Fatalf("IterativeDeepening: %v", err)
+			}
+			if err := g.SetPlayerMove(move, Nought); err != nil {
+				t.Errorf("move %v: %v", move, err)
+			}
+		})
+	}
+}
+
+func TestThreatMoveWalledOff(t *testing.T) {
+	g := walledGame(t)
+	move := ThreatMove(g, Nought)
+	if err := g.SetPlayerMove(move, Nought); err != nil {
+		t.Errorf("move %v: %v", move, err)
+	}
+}
@@ -1,6 +1,70 @@
 package game
 
-import "math"
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// searchKey identifies a canonical position and the player to move in it
+type searchKey struct {
+	board  Bitboard
+	player Mark
+}
+
+// searchCache keeps all optimal moves found by Minimax for positions of the standard board.
+// Keys are canonical, so all symmetric images of a position share one entry.
+var searchCache sync.Map
+
+// SearchBestMove returns the Minimax move for the current player, reusing on the standard
+// board the result cached for the position or any of its symmetric images.
+// The search runs on at most workers goroutines, GOMAXPROCS if workers is not positive.
+// Returns NoCoord if there is no move.
+func SearchBestMove(g *Game, currentPlayer Mark, workers int) Coord {
+	moves := SearchBestMoves(g, currentPlayer, workers)
+	if len(moves) == 0 {
+		return NoCoord
+	}
+	return moves[0]
+}
+
+// SearchBestMoves returns all moves with the best Minimax score for the current player
+// in the order of the board, cached on the standard board like SearchBestMove.
+// Returns nil if there is no move.
+func SearchBestMoves(g *Game, currentPlayer Mark, workers int) []Coord {
+	if !g.Rules.IsStandard() {
+		_, moves := MinimaxMoves(g, currentPlayer, workers)
+		return moves
+	}
+
+	board, sym := g.Grid.CanonicalBitboard()
+	key := searchKey{board: board, player: currentPlayer}
+
+	value, ok := searchCache.Load(key)
+	if !ok {
+		canonical := Game{Grid: board.Grid(), Rules: g.Rules}
+		_, moves := MinimaxMoves(&canonical, currentPlayer, workers)
+		value, _ = searchCache.LoadOrStore(key, moves)
+	}
+
+	inverse := sym.Inverse()
+	var moves []Coord
+	for _, move := range value.([]Coord) {
+		moves = append(moves, inverse.MapCoord(move, GridSize))
+	}
+	sortCoords(moves)
+	return moves
+}
+
+// sortCoords sorts coordinates in the order of the board, row by row
+func sortCoords(coords []Coord) {
+	sort.Slice(coords, func(i, j int) bool {
+		if coords[i].Row != coords[j].Row {
+			return coords[i].Row < coords[j].Row
+		}
+		return coords[i].Col < coords[j].Col
+	})
+}
 
 // Minimax implements the Minimax algorithm to evaluate the best move for the current player.
 // It recursively simulates all possible game outcomes and chooses the move that leads to the best result.
@@ -17,8 +81,8 @@ func Minimax(g *Game, currentPlayer Mark, depth int) (int, Coord) {
 
 	depth++
 
-	for i := 0; i < GridSize; i++ {
-		for j := 0; j < GridSize; j++ {
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
 			if g.Grid[i][j] == Empty {
 				currCoord := Coord{i, j}
 				g.Grid[i][j] = currentPlayer
@@ -36,7 +100,8 @@ func Minimax(g *Game, currentPlayer Mark, depth int) (int, Coord) {
 }
 
 // CalculateWinPoints returns a score based on the winner and current depth.
-// Used in the Minimax algorithm to evaluate terminal game states.
+// Used in the Minimax algorithm to evaluate terminal game states. The winner is the one
+// reported by Game.IsOver, so under the misere rule the player who completed a line scores the loss.
 func CalculateWinPoints(winner Mark, depth int) int {
 	if winner == Cross {
 		return 10 - depth
@@ -70,6 +135,11 @@ func GetOpponent(currentPlayer Mark) Mark {
 	return opponent
 }
 
+// isPlayer reports whether the mark belongs to a player, blocked cells belong to neither
+func (m Mark) isPlayer() bool {
+	return m == Cross || m == Nought
+}
+
 // UpdateBestScore compares the given score with the current bestScore based on the player's role.
 // For Cross (maximizing player), it updates if the score is greater.
 // For Nought (minimizing player), it updates if the score is lower.
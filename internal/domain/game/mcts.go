@@ -0,0 +1,169 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// DefaultMCTSIterations is the number of playouts used when no search budget is configured
+const DefaultMCTSIterations = 20000
+
+// DefaultExploration is the UCT exploration constant (square root of 2)
+var DefaultExploration = math.Sqrt2
+
+// MCTSConfig holds the search budget of the Monte Carlo Tree Search engine.
+// The search stops when any of the limits is reached.
+type MCTSConfig struct {
+	Iterations  int           // Maximum number of playouts, 0 for no limit
+	TimeLimit   time.Duration // Maximum search time, 0 for no limit
+	Seed        int64         // Seed of the random source, 0 to seed from the clock
+	Exploration float64       // UCT exploration constant, DefaultExploration if 0
+}
+
+// mctsNode is a node of the search tree: the position after move was made by player
+type mctsNode struct {
+	move     Coord
+	player   Mark
+	depth    int
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []Coord
+	visits   int
+	score    float64
+}
+
+// MCTS returns the move for the current player found by Monte Carlo Tree Search with UCT selection
+// and the depth of the deepest expanded node. The search also stops when ctx is done.
+// Searches bounded only by iterations are deterministic for a non-zero seed.
+// Returns an error if the game is over.
+func MCTS(ctx context.Context, g *Game, currentPlayer Mark, cfg MCTSConfig) (Coord, int, error) {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return NoCoord, 0, fmt.Errorf("could not find a valid move")
+	}
+
+	if cfg.Iterations == 0 && cfg.TimeLimit == 0 {
+		cfg.Iterations = DefaultMCTSIterations
+	}
+	if cfg.Exploration == 0 {
+		cfg.Exploration = DefaultExploration
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	root := &mctsNode{player: GetOpponent(currentPlayer), untried: g.LegalMoves()}
+	deadline := time.Now().Add(cfg.TimeLimit)
+	maxDepth := 0
+
+	for i := 0; cfg.Iterations == 0 || i < cfg.Iterations; i++ {
+		if cfg.TimeLimit > 0 && time.Now().After(deadline) {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		sim := g.Clone()
+		node := root
+
+		for len(node.untried) == 0 && len(node.children) > 0 {
+			node = node.selectChild(cfg.Exploration)
+			sim.Play(node.move, node.player)
+		}
+
+		if len(node.untried) > 0 {
+			k := rnd.Intn(len(node.untried))
+			move := node.untried[k]
+			node.untried = append(node.untried[:k], node.untried[k+1:]...)
+
+			player := GetOpponent(node.player)
+			sim.Play(move, player)
+			child := &mctsNode{move: move, player: player, depth: node.depth + 1, parent: node}
+			maxDepth = max(maxDepth, child.depth)
+			if !sim.wins(move) {
+				child.untried = sim.LegalMoves()
+			}
+			node.children = append(node.children, child)
+			node = child
+		}
+
+		var winner Mark
+		if node != root && sim.wins(node.move) {
+			winner = sim.lineWinner(node.player)
+		} else {
+			winner = sim.playout(GetOpponent(node.player), rnd)
+		}
+
+		for n := node; n != nil; n = n.parent {
+			n.visits++
+			if winner == n.player {
+				n.score++
+			} else if winner == Empty {
+				n.score += 0.5
+			}
+		}
+	}
+
+	best := root.mostVisitedChild()
+	if best == nil && g.Rules.Variant == Classic {
+		return HeuristicMove(g, currentPlayer), 0, nil
+	}
+	if best == nil {
+		return root.untried[0], 0, nil
+	}
+	return best.move, maxDepth, nil
+}
+
+// selectChild returns the child with the highest UCT value
+func (n *mctsNode) selectChild(exploration float64) *mctsNode {
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	logVisits := math.Log(float64(n.visits))
+
+	for _, child := range n.children {
+		value := child.score/float64(child.visits) + exploration*math.Sqrt(logVisits/float64(child.visits))
+		if value > bestValue {
+			best, bestValue = child, value
+		}
+	}
+	return best
+}
+
+// mostVisitedChild returns the child explored the most times, or nil if there are no children
+func (n *mctsNode) mostVisitedChild() *mctsNode {
+	var best *mctsNode
+	for _, child := range n.children {
+		if best == nil || child.visits > best.visits {
+			best = child
+		}
+	}
+	return best
+}
+
+// playout plays random moves starting with the current player until the game ends
+// and returns the winner, or Empty for a draw
+func (g *Game) playout(currentPlayer Mark, rnd *rand.Rand) Mark {
+	if g.Rules.Variant == Ultimate {
+		return g.ultimatePlayout(currentPlayer, rnd)
+	}
+	if g.Rules.Gravity {
+		return g.gravityPlayout(currentPlayer, rnd)
+	}
+
+	cells := g.EmptyCells()
+	rnd.Shuffle(len(cells), func(i, j int) { cells[i], cells[j] = cells[j], cells[i] })
+
+	for _, c := range cells {
+		g.Grid[c.Row][c.Col] = currentPlayer
+		if g.completesLine(c) {
+			return g.lineWinner(currentPlayer)
+		}
+		currentPlayer = GetOpponent(currentPlayer)
+	}
+	return Empty
+}
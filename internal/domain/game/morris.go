@@ -0,0 +1,441 @@
+package game
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// MorrisPieces is the number of marks every player has in morris games
+const MorrisPieces = 3
+
+// MorrisRepetitions is the number of occurrences of the same position that draws a morris game
+const MorrisRepetitions = 3
+
+// Step is a move of a morris game: a new mark placed in To, or the mark in From moved to To
+type Step struct {
+	From, To Coord // From is NoCoord for placements
+}
+
+// morrisStep is a Step on a Bitboard: cell indexes row*GridSize+col, from is -1 for placements
+type morrisStep struct {
+	from, to int
+}
+
+// morrisValue is the perfect-play result of a morris position for the player to move:
+// 1 for a win, -1 for a loss and 0 for a draw, with the number of plies to the end of a decided game
+type morrisValue struct {
+	result int
+	dist   int
+}
+
+// cellsMask is the mask of all cells of a Bitboard half
+const cellsMask = 1<<(GridSize*GridSize) - 1
+
+// morrisSideBit is set in the position key when Nought is to move
+const morrisSideBit = 1 << 30
+
+// standardLines are the masks of the winning lines of the standard board, morrisNeighbours
+// the masks of the cells next to every cell along a line: the marks of morris games move along them
+var standardLines, morrisNeighbours = boardLines()
+
+// morrisTable keeps the perfect-play values of all morris positions, solved once on first use
+var morrisTable struct {
+	once   sync.Once
+	values map[uint32]morrisValue
+}
+
+// boardLines returns the masks of the lines of the standard board and of the neighbours of every cell along them
+func boardLines() ([]uint16, [GridSize * GridSize]uint16) {
+	var lines []uint16
+	var neighbours [GridSize * GridSize]uint16
+	for i := 0; i < GridSize; i++ {
+		for j := 0; j < GridSize; j++ {
+			for _, d := range lineDirections {
+				end := Coord{Row: i + d.Row*(GridSize-1), Col: j + d.Col*(GridSize-1)}
+				if end.Row < 0 || end.Row >= GridSize || end.Col < 0 || end.Col >= GridSize {
+					continue
+				}
+
+				var line uint16
+				prev := -1
+				for k := 0; k < GridSize; k++ {
+					cell := (i+d.Row*k)*GridSize + j + d.Col*k
+					line |= 1 << cell
+					if prev >= 0 {
+						neighbours[prev] |= 1 << cell
+						neighbours[cell] |= 1 << prev
+					}
+					prev = cell
+				}
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines, neighbours
+}
+
+// hasLine reports whether the marks of the mask fill a line of the standard board
+func hasLine(mask uint16) bool {
+	for _, line := range standardLines {
+		if mask&line == line {
+			return true
+		}
+	}
+	return false
+}
+
+// PlayStep makes the step with the mark and records it without any checks
+func (g *Game) PlayStep(step Step, mark Mark) {
+	if step.From != NoCoord {
+		g.Grid[step.From.Row][step.From.Col] = Empty
+	}
+	g.Grid[step.To.Row][step.To.Col] = mark
+	g.Steps = append(g.Steps, step)
+}
+
+// SetStep checks the step of the current player in a morris game and makes it if it is correct:
+// a player places marks until they have MorrisPieces of them, then moves one of them to an empty
+// cell next to it along a line. Returns an error if the step is not possible
+func (g *Game) SetStep(step Step, currentPlayer Mark) error {
+	if g.Rules.Variant != Morris {
+		return fmt.Errorf("no move possible: marks are only moved in morris games")
+	}
+
+	gameOver, _ := g.IsOver()
+	if gameOver {
+		g.State = Completed
+		return fmt.Errorf("no move possible: game is over")
+	}
+
+	if !g.inside(step.To) {
+		return fmt.Errorf("no move possible: no such cell")
+	}
+	if g.Grid[step.To.Row][step.To.Col] != Empty {
+		return fmt.Errorf("no move possible: cell is occupied")
+	}
+
+	if g.countMarks(currentPlayer) < MorrisPieces {
+		if step.From != NoCoord {
+			return fmt.Errorf("no move possible: marks are placed until each player has %d", MorrisPieces)
+		}
+		g.PlayStep(step, currentPlayer)
+		return nil
+	}
+
+	if step.From == NoCoord {
+		return fmt.Errorf("no move possible: all marks are placed, one has to be moved")
+	}
+	if !g.inside(step.From) || g.Grid[step.From.Row][step.From.Col] != currentPlayer {
+		return fmt.Errorf("no move possible: no own mark to move")
+	}
+	if morrisNeighbours[step.From.Row*GridSize+step.From.Col]&(1<<(step.To.Row*GridSize+step.To.Col)) == 0 {
+		return fmt.Errorf("no move possible: cell is not next to the mark")
+	}
+
+	g.PlayStep(step, currentPlayer)
+	return nil
+}
+
+// LegalSteps returns the steps the player to move can make in a morris game
+func (g *Game) LegalSteps() []Step {
+	var steps []Step
+	for _, s := range morrisSteps(g.Grid.Bitboard(), g.PlayerToMove()) {
+		steps = append(steps, s.step())
+	}
+	return steps
+}
+
+// Repetitions returns how many times the current position of a morris game, with the same player to move,
+// has occurred in the game, counting the current one
+func (g *Game) Repetitions() int {
+	grid := NewGrid(GridSize)
+	count := 0
+	if len(g.Steps)%2 == 0 && grid.Equal(g.Grid) {
+		count++
+	}
+
+	for i, step := range g.Steps {
+		mark := Cross
+		if i%2 == 1 {
+			mark = Nought
+		}
+		if step.From != NoCoord {
+			grid[step.From.Row][step.From.Col] = Empty
+		}
+		grid[step.To.Row][step.To.Col] = mark
+
+		if (len(g.Steps)-i-1)%2 == 0 && grid.Equal(g.Grid) {
+			count++
+		}
+	}
+	return count
+}
+
+// countMarks returns the number of the marks on the board
+func (g *Game) countMarks(mark Mark) int {
+	count := 0
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
+			if g.Grid[i][j] == mark {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// morrisOver reports that a morris game is over when a player has a line, when the player to move
+// has no step and loses, or when the position occurs for the MorrisRepetitions time, which is a draw.
+// The board is finite, so every long enough game repeats a position
+func (g *Game) morrisOver() (bool, Mark) {
+	for i := range g.Grid {
+		for j := range g.Grid[i] {
+			if g.Grid[i][j] != Empty && g.lineFrom(Coord{Row: i, Col: j}) {
+				return true, g.Grid[i][j]
+			}
+		}
+	}
+
+	if len(morrisSteps(g.Grid.Bitboard(), g.PlayerToMove())) == 0 {
+		return true, GetOpponent(g.PlayerToMove())
+	}
+
+	if g.Repetitions() >= MorrisRepetitions {
+		return true, Empty
+	}
+	return false, Empty
+}
+
+// MorrisMove returns the perfect-play step of the player to move in a morris game and the number of plies
+// to the end of the game, 0 for a drawn position. The values of all positions are found once by retrograde
+// analysis, which is safe on the cyclic graph of morris positions: a win takes the step to the fastest lost
+// position of the opponent, a loss the step to the slowest win. Drawing steps prefer positions repeated least.
+// Returns an error if the game is over
+func MorrisMove(g *Game) (Step, int, error) {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return Step{From: NoCoord, To: NoCoord}, 0, fmt.Errorf("could not find a valid move")
+	}
+
+	values := solveMorris()
+	board, toMove := g.Grid.Bitboard(), g.PlayerToMove()
+	value := values[morrisKey(board, toMove)]
+
+	best, bestRank := morrisStep{from: -1, to: -1}, 0
+	for _, s := range morrisSteps(board, toMove) {
+		child := values[morrisKey(s.apply(board, toMove), GetOpponent(toMove))]
+
+		var rank int
+		switch {
+		case child.result != -value.result:
+			continue
+		case value.result > 0:
+			rank = -child.dist
+		case value.result < 0:
+			rank = child.dist
+		default:
+			sim := g.Clone()
+			sim.PlayStep(s.step(), toMove)
+			rank = -sim.Repetitions()
+		}
+
+		if best.to < 0 || rank > bestRank {
+			best, bestRank = s, rank
+		}
+	}
+
+	if best.to < 0 {
+		return Step{From: NoCoord, To: NoCoord}, 0, fmt.Errorf("could not find a valid move")
+	}
+	return best.step(), value.dist, nil
+}
+
+// solveMorris returns the values of all morris positions, solving them on first use.
+// Lost positions without moves or with a line of the opponent are found first, then every round
+// resolves the positions with a lost child as won and those with only won children as lost.
+// Positions left when no round changes anything are draws
+func solveMorris() map[uint32]morrisValue {
+	morrisTable.once.Do(func() {
+		values := map[uint32]morrisValue{}
+		children := map[uint32][]uint32{}
+
+		for _, p := range morrisPositions() {
+			key := morrisKey(p.board, p.toMove)
+			own, opponent := markMasks(p.board, p.toMove)
+			switch {
+			case hasLine(opponent):
+				values[key] = morrisValue{result: -1}
+			case hasLine(own):
+				values[key] = morrisValue{result: 1}
+			default:
+				steps := morrisSteps(p.board, p.toMove)
+				if len(steps) == 0 {
+					values[key] = morrisValue{result: -1}
+				}
+				for _, s := range steps {
+					children[key] = append(children[key], morrisKey(s.apply(p.board, p.toMove), GetOpponent(p.toMove)))
+				}
+			}
+		}
+
+		for {
+			resolved := map[uint32]morrisValue{}
+			for key, keys := range children {
+				if _, ok := values[key]; ok {
+					continue
+				}
+
+				win, loss, allWon := -1, -1, true
+				for _, child := range keys {
+					value, ok := values[child]
+					switch {
+					case ok && value.result < 0 && (win < 0 || value.dist+1 < win):
+						win = value.dist + 1
+					case !ok || value.result == 0:
+						allWon = false
+					case value.result > 0 && value.dist+1 > loss:
+						loss = value.dist + 1
+					}
+				}
+
+				if win >= 0 {
+					resolved[key] = morrisValue{result: 1, dist: win}
+				} else if allWon {
+					resolved[key] = morrisValue{result: -1, dist: loss}
+				}
+			}
+
+			if len(resolved) == 0 {
+				break
+			}
+			for key, value := range resolved {
+				values[key] = value
+			}
+		}
+
+		for key := range children {
+			if _, ok := values[key]; !ok {
+				values[key] = morrisValue{}
+			}
+		}
+		morrisTable.values = values
+	})
+	return morrisTable.values
+}
+
+// morrisPosition is a morris board with the player to move
+type morrisPosition struct {
+	board  Bitboard
+	toMove Mark
+}
+
+// morrisPositions returns all boards with at most MorrisPieces marks of each player that can occur
+// in a game started by Cross, with the players who can be to move there: Cross when both have
+// the same number of marks, Nought when Cross has one more, either when all marks are placed
+func morrisPositions() []morrisPosition {
+	var positions []morrisPosition
+	cells := GridSize * GridSize
+	total := 1
+	for i := 0; i < cells; i++ {
+		total *= 3
+	}
+
+	for code := 0; code < total; code++ {
+		var board Bitboard
+		rest := code
+		for cell := 0; cell < cells; cell++ {
+			switch Mark(rest % 3) {
+			case Cross:
+				board |= 1 << cell
+			case Nought:
+				board |= 1 << (cell + noughtShift)
+			}
+			rest /= 3
+		}
+
+		crosses, noughts := markMasks(board, Cross)
+		c, n := bits.OnesCount16(crosses), bits.OnesCount16(noughts)
+		switch {
+		case c > MorrisPieces || n > MorrisPieces:
+		case c == n && c == MorrisPieces:
+			positions = append(positions, morrisPosition{board, Cross}, morrisPosition{board, Nought})
+		case c == n:
+			positions = append(positions, morrisPosition{board, Cross})
+		case c == n+1:
+			positions = append(positions, morrisPosition{board, Nought})
+		}
+	}
+	return positions
+}
+
+// morrisKey returns the key of the position in the values of morrisTable
+func morrisKey(board Bitboard, toMove Mark) uint32 {
+	if toMove == Nought {
+		return uint32(board) | morrisSideBit
+	}
+	return uint32(board)
+}
+
+// markMasks returns the cell masks of the marks of the player and of the opponent
+func markMasks(board Bitboard, player Mark) (uint16, uint16) {
+	crosses, noughts := uint16(board&cellsMask), uint16(board>>noughtShift&cellsMask)
+	if player == Nought {
+		return noughts, crosses
+	}
+	return crosses, noughts
+}
+
+// morrisSteps returns the steps of the player on the board: placements into the empty cells
+// while the player has less than MorrisPieces marks, then moves of a mark to an empty neighbour
+func morrisSteps(board Bitboard, player Mark) []morrisStep {
+	own, opponent := markMasks(board, player)
+	empty := ^(own | opponent) & cellsMask
+
+	var steps []morrisStep
+	if bits.OnesCount16(own) < MorrisPieces {
+		for cell := 0; cell < GridSize*GridSize; cell++ {
+			if empty&(1<<cell) != 0 {
+				steps = append(steps, morrisStep{from: -1, to: cell})
+			}
+		}
+		return steps
+	}
+
+	for from := 0; from < GridSize*GridSize; from++ {
+		if own&(1<<from) == 0 {
+			continue
+		}
+		for to := 0; to < GridSize*GridSize; to++ {
+			if morrisNeighbours[from]&empty&(1<<to) != 0 {
+				steps = append(steps, morrisStep{from: from, to: to})
+			}
+		}
+	}
+	return steps
+}
+
+// apply returns the board after the player makes the step
+func (s morrisStep) apply(board Bitboard, player Mark) Bitboard {
+	shift := 0
+	if player == Nought {
+		shift = noughtShift
+	}
+	if s.from >= 0 {
+		board &^= 1 << (s.from + shift)
+	}
+	return board | 1<<(s.to+shift)
+}
+
+// step converts the step into board coordinates
+func (s morrisStep) step() Step {
+	step := Step{From: NoCoord, To: Coord{Row: s.to / GridSize, Col: s.to % GridSize}}
+	if s.from >= 0 {
+		step.From = Coord{Row: s.from / GridSize, Col: s.from % GridSize}
+	}
+	return step
+}
+
+// Placing reports whether the player to move in a morris game still places marks
+func (g *Game) Placing() bool {
+	return g.countMarks(g.PlayerToMove()) < MorrisPieces
+}
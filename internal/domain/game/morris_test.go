@@ -0,0 +1,199 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// morrisGame returns a new morris game after the steps, failing the test if one is rejected
+func morrisGame(t *testing.T, steps ...Step) *Game {
+	t.Helper()
+	g, err := NewGameWithRules(Morris.Rules())
+	if err != nil {
+		t.Fatalf("NewGameWithRules: %v", err)
+	}
+	for _, step := range steps {
+		if err := g.SetStep(step, g.PlayerToMove()); err != nil {
+			t.Fatalf("step %v: %v", step, err)
+		}
+	}
+	return g
+}
+
+// place returns the placement step to the cell
+func place(row, col int) Step {
+	return Step{From: NoCoord, To: Coord{Row: row, Col: col}}
+}
+
+// move returns the step of the mark from one cell to another
+func move(fromRow, fromCol, toRow, toCol int) Step {
+	return Step{From: Coord{Row: fromRow, Col: fromCol}, To: Coord{Row: toRow, Col: toCol}}
+}
+
+// morrisPlacements places X in (0,0), (1,2), (2,1) and O in (0,1), (1,0), (2,2): no lines, every player can move
+var morrisPlacements = []Step{place(0, 0), place(0, 1), place(1, 2), place(1, 0), place(2, 1), place(2, 2)}
+
+func TestMorrisMill(t *testing.T) {
+	g := morrisGame(t, place(0, 0), place(1, 1), place(0, 1), place(2, 2))
+	if over, _ := g.IsOver(); over {
+		t.Fatal("game over without a line")
+	}
+
+	if err := g.SetStep(place(0, 2), Cross); err != nil {
+		t.Fatal(err)
+	}
+	if over, winner := g.IsOver(); !over || winner != Cross {
+		t.Fatalf("IsOver = %v, %v, want the mill to win", over, winner)
+	}
+	if err := g.SetStep(place(2, 0), Nought); err == nil {
+		t.Error("step after the mill accepted")
+	}
+
+	// a mill made by moving a mark wins as well
+	g = morrisGame(t, place(0, 0), place(1, 0), place(0, 1), place(2, 1), place(1, 2), place(2, 2))
+	if over, _ := g.IsOver(); over {
+		t.Fatal("game over without a line")
+	}
+	if err := g.SetStep(move(1, 2, 0, 2), Cross); err != nil {
+		t.Fatal(err)
+	}
+	if over, winner := g.IsOver(); !over || winner != Cross {
+		t.Errorf("IsOver = %v, %v, want the moved mill to win", over, winner)
+	}
+}
+
+func TestMorrisIllegalSteps(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []Step
+		step  Step
+	}{
+		{name: "move while placing", steps: []Step{place(0, 0), place(1, 1)}, step: move(0, 0, 0, 1)},
+		{name: "place after all placed", steps: morrisPlacements, step: place(1, 1)},
+		{name: "move to a cell not next to the mark", steps: morrisPlacements, step: move(0, 0, 2, 0)},
+		{name: "move along no line", steps: morrisPlacements, step: move(2, 1, 1, 2)},
+		{name: "move to an occupied cell", steps: morrisPlacements, step: move(0, 0, 0, 1)},
+		{name: "move a mark of the opponent", steps: morrisPlacements, step: move(0, 1, 1, 1)},
+		{name: "move from an empty cell", steps: morrisPlacements, step: move(1, 1, 0, 2)},
+		{name: "place on an occupied cell", steps: []Step{place(0, 0)}, step: place(0, 0)},
+		{name: "place off the board", steps: nil, step: place(3, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := morrisGame(t, tt.steps...)
+			before := g.Grid.Clone()
+			if err := g.SetStep(tt.step, g.PlayerToMove()); err == nil {
+				t.Errorf("step %v accepted", tt.step)
+			}
+			if !g.Grid.Equal(before) || len(g.Steps) != len(tt.steps) {
+				t.Errorf("rejected step changed the game")
+			}
+		})
+	}
+}
+
+func TestMorrisRepetition(t *testing.T) {
+	cycle := []Step{move(0, 0, 1, 1), move(0, 1, 0, 2), move(1, 1, 0, 0), move(0, 2, 0, 1)}
+	g := morrisGame(t, append(morrisPlacements, cycle...)...)
+	if n := g.Repetitions(); n != 2 {
+		t.Errorf("Repetitions = %d after one cycle, want 2", n)
+	}
+	if over, _ := g.IsOver(); over {
+		t.Fatal("game over after the second occurrence")
+	}
+
+	for _, step := range cycle {
+		if err := g.SetStep(step, g.PlayerToMove()); err != nil {
+			t.Fatalf("step %v: %v", step, err)
+		}
+	}
+	if over, winner := g.IsOver(); !over || winner != Empty {
+		t.Errorf("IsOver = %v, %v after the third occurrence, want a draw", over, winner)
+	}
+}
+
+func TestSolveMorrisConsistent(t *testing.T) {
+	values := solveMorris()
+	for _, p := range morrisPositions() {
+		key := morrisKey(p.board, p.toMove)
+		value := values[key]
+		own, opponent := markMasks(p.board, p.toMove)
+		if hasLine(own) || hasLine(opponent) {
+			continue
+		}
+
+		steps := morrisSteps(p.board, p.toMove)
+		lost, drawn, fastest := false, false, -1
+		for _, s := range steps {
+			child := values[morrisKey(s.apply(p.board, p.toMove), GetOpponent(p.toMove))]
+			switch {
+			case child.result < 0:
+				lost = true
+				if fastest < 0 || child.dist+1 < fastest {
+					fastest = child.dist + 1
+				}
+			case child.result == 0:
+				drawn = true
+			}
+		}
+
+		switch {
+		case value.result > 0 && (!lost || value.dist != fastest):
+			t.Fatalf("%v, %v to move: won in %d, fastest lost child in %d", p.board.Grid(), p.toMove, value.dist, fastest)
+		case value.result == 0 && (lost || !drawn):
+			t.Fatalf("%v, %v to move: drawn with a lost child %v or no drawn one %v", p.board.Grid(), p.toMove, lost, drawn)
+		case value.result < 0 && (lost || drawn):
+			t.Fatalf("%v, %v to move: lost with a child that isn't won", p.board.Grid(), p.toMove)
+		}
+	}
+}
+
+func TestMorrisMoveKeepsValue(t *testing.T) {
+	values := solveMorris()
+	value := func(g *Game) morrisValue {
+		return values[morrisKey(g.Grid.Bitboard(), g.PlayerToMove())]
+	}
+
+	// results of the empty board for each player under perfect play
+	start := map[Mark]int{Cross: value(morrisGame(t)).result}
+	start[Nought] = -start[Cross]
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		g := morrisGame(t)
+		solver := []Mark{Cross, Nought}[i%2]
+
+		for {
+			if over, _ := g.IsOver(); over {
+				break
+			}
+
+			mover := g.PlayerToMove()
+			if mover != solver {
+				steps := g.LegalSteps()
+				g.PlayStep(steps[rnd.Intn(len(steps))], mover)
+				continue
+			}
+
+			before := value(g)
+			step, _, err := MorrisMove(g)
+			if err != nil {
+				t.Fatalf("game %d: MorrisMove: %v", i, err)
+			}
+			if err := g.SetStep(step, mover); err != nil {
+				t.Fatalf("game %d: step %v: %v", i, step, err)
+			}
+			if over, winner := g.IsOver(); over && winner == mover {
+				continue
+			}
+			if after := value(g); after.result != -before.result {
+				t.Fatalf("game %d: step %v turns the result %d into %d", i, step, before.result, -after.result)
+			}
+		}
+
+		if _, winner := g.IsOver(); winner == GetOpponent(solver) && start[solver] >= 0 {
+			t.Errorf("game %d: solver playing %v lost the position of result %d", i, solver, start[solver])
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// NotaktoBoards and MaxNotaktoBoards - the default and the largest number of boards of notakto games
+const (
+	NotaktoBoards    = 3
+	MaxNotaktoBoards = 5
+)
+
+// notaktoTables holds the encodings of all 512 sets of crosses on a board:
+// canonical is the smallest image of the set under the board symmetries, dead tells the sets with a line
+var notaktoTables struct {
+	once      sync.Once
+	canonical [1 << (GridSize * GridSize)]uint16
+	dead      [1 << (GridSize * GridSize)]bool
+}
+
+// notaktoMemo keeps the solved notakto positions: whether the player to move wins
+var notaktoMemo sync.Map
+
+// notaktoSolver keeps the state of one notakto solve
+type notaktoSolver struct {
+	ctx     context.Context
+	nodes   int
+	aborted bool
+}
+
+// initNotaktoTables fills notaktoTables once
+func initNotaktoTables() {
+	notaktoTables.once.Do(func() {
+		for mask := range notaktoTables.canonical {
+			grid := Bitboard(mask).Grid()
+			canonical, _ := grid.CanonicalBitboard()
+			notaktoTables.canonical[mask] = uint16(canonical)
+
+			g := Game{Grid: grid, Rules: DefaultRules()}
+			over, winner := g.IsOver()
+			notaktoTables.dead[mask] = over && winner != Empty
+		}
+	})
+}
+
+// BoardDead reports whether the board of a notakto game has a line of crosses and is out of play
+func (g *Game) BoardDead(board int) bool {
+	initNotaktoTables()
+	return notaktoTables.dead[boardMask(g.Boards[board])]
+}
+
+// notaktoOver reports that a notakto game is over when all boards are dead.
+// The player who killed the last board loses, so the player to move wins
+func (g *Game) notaktoOver() (bool, Mark) {
+	for b := range g.Boards {
+		if !g.BoardDead(b) {
+			return false, Empty
+		}
+	}
+	return true, g.PlayerToMove()
+}
+
+// boardMask encodes the crosses of a board as bits row*GridSize+col
+func boardMask(board Grid) uint16 {
+	return uint16(board.Bitboard())
+}
+
+// NotaktoMove returns the move of the player to move in a notakto game found by the solver.
+// The solver searches the positions as sorted sets of the canonical live boards, so equivalent
+// positions are solved once and kept for later games. A winning move is returned if there is one,
+// otherwise, or if ctx is done before the position is solved, a move that keeps its board alive
+// when possible. Also returns whether the position was solved.
+// Returns an error if the game is over
+func NotaktoMove(ctx context.Context, g *Game) (BoardCoord, bool, error) {
+	moves := g.LegalBoardMoves()
+	if gameOver, _ := g.IsOver(); gameOver || len(moves) == 0 {
+		return NoBoardCoord, false, fmt.Errorf("could not find a valid move")
+	}
+
+	initNotaktoTables()
+	s := notaktoSolver{ctx: ctx}
+	live := g.liveBoards()
+
+	safe := NoBoardCoord
+	for _, move := range moves {
+		mask := boardMask(g.Boards[move.Board])
+		next := mask | 1<<(move.Row*GridSize+move.Col)
+		if !notaktoTables.dead[next] && safe == NoBoardCoord {
+			safe = move
+		}
+
+		if s.aborted {
+			continue
+		}
+		if !s.wins(afterNotaktoMove(live, mask, next)) && !s.aborted {
+			return move, true, nil
+		}
+	}
+
+	if safe == NoBoardCoord {
+		return moves[0], !s.aborted, nil
+	}
+	return safe, !s.aborted, nil
+}
+
+// liveBoards returns the canonical encodings of the live boards of the game
+func (g *Game) liveBoards() []uint16 {
+	var live []uint16
+	for b, board := range g.Boards {
+		if !g.BoardDead(b) {
+			live = append(live, notaktoTables.canonical[boardMask(board)])
+		}
+	}
+	return live
+}
+
+// afterNotaktoMove returns the sorted canonical live boards after a move changes the board
+// from mask to next: the board is replaced by its new state or removed if the move killed it
+func afterNotaktoMove(live []uint16, mask, next uint16) []uint16 {
+	after := make([]uint16, 0, len(live))
+	replaced := false
+	for _, board := range live {
+		if !replaced && board == notaktoTables.canonical[mask] {
+			replaced = true
+			continue
+		}
+		after = append(after, board)
+	}
+
+	if !notaktoTables.dead[next] {
+		after = append(after, notaktoTables.canonical[next])
+	}
+	sort.Slice(after, func(i, j int) bool { return after[i] < after[j] })
+	return after
+}
+
+// wins reports whether the player to move wins the position of sorted canonical live boards.
+// With no live boards left the opponent has killed the last one, so the player to move has won.
+// Positions solved before ctx is done are kept in notaktoMemo
+func (s *notaktoSolver) wins(live []uint16) bool {
+	if len(live) == 0 {
+		return true
+	}
+
+	s.nodes++
+	if s.nodes%1024 == 0 && s.ctx.Err() != nil {
+		s.aborted = true
+	}
+	if s.aborted {
+		return false
+	}
+
+	key := notaktoKey(live)
+	if value, ok := notaktoMemo.Load(key); ok {
+		return value.(bool)
+	}
+
+	win := false
+	for i, board := range live {
+		if i > 0 && board == live[i-1] {
+			continue
+		}
+		for cell := 0; cell < GridSize*GridSize && !win; cell++ {
+			bit := uint16(1) << cell
+			if board&bit != 0 {
+				continue
+			}
+			win = !s.wins(afterNotaktoMove(live, board, board|bit))
+		}
+		if win {
+			break
+		}
+	}
+
+	if s.aborted {
+		return false
+	}
+	notaktoMemo.Store(key, win)
+	return win
+}
+
+// notaktoKey packs the sorted canonical live boards into a map key, ten bits per board
+// with the tenth bit set, so positions with different numbers of boards differ
+func notaktoKey(live []uint16) uint64 {
+	var key uint64
+	for _, board := range live {
+		key = key<<10 | uint64(board) | 1<<9
+	}
+	return key
+}
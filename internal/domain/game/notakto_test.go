@@ -0,0 +1,114 @@
+package game
+
+import (
+	"context"
+	"testing"
+)
+
+// notaktoGame returns a new notakto game on the given number of boards
+func notaktoGame(t *testing.T, boards int) *Game {
+	t.Helper()
+	rules := Notakto.Rules()
+	rules.Boards = boards
+	g, err := NewGameWithRules(rules)
+	if err != nil {
+		t.Fatalf("NewGameWithRules: %v", err)
+	}
+	return g
+}
+
+// notaktoLines are the cells of the eight lines of a board as row-major bits
+var notaktoLines = []uint16{
+	0b000000111, 0b000111000, 0b111000000,
+	0b001001001, 0b010010010, 0b100100100,
+	0b100010001, 0b001010100,
+}
+
+// bruteNotakto reports whether the player to move wins on one board with the crosses of mask,
+// searched without symmetries and memo
+func bruteNotakto(mask uint16) bool {
+	for cell := 0; cell < GridSize*GridSize; cell++ {
+		next := mask | 1<<cell
+		if next == mask {
+			continue
+		}
+		dead := false
+		for _, line := range notaktoLines {
+			dead = dead || next&line == line
+		}
+		if !dead && !bruteNotakto(next) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNotaktoOneBoard(t *testing.T) {
+	g := notaktoGame(t, 1)
+	move, solved, err := NotaktoMove(context.Background(), g)
+	if err != nil || !solved {
+		t.Fatalf("NotaktoMove = %v, solved %v, %v", move, solved, err)
+	}
+
+	// the first player wins by the centre opening, and the move found wins as well
+	centre := uint16(1) << (GridSize * GridSize / 2)
+	if bruteNotakto(centre) {
+		t.Error("the second player wins after the centre opening")
+	}
+	if bruteNotakto(boardMask(g.Boards[0]) | 1<<(move.Row*GridSize+move.Col)) {
+		t.Errorf("NotaktoMove %v doesn't win", move)
+	}
+
+	initNotaktoTables()
+	s := notaktoSolver{ctx: context.Background()}
+	for mask := uint16(0); mask < 1<<(GridSize*GridSize); mask++ {
+		if notaktoTables.dead[mask] {
+			continue
+		}
+		if got, want := s.wins([]uint16{notaktoTables.canonical[mask]}), bruteNotakto(mask); got != want {
+			t.Fatalf("board %09b: wins = %v, want %v", mask, got, want)
+		}
+	}
+}
+
+func TestNotaktoDeadBoards(t *testing.T) {
+	g := notaktoGame(t, 2)
+	for _, move := range []BoardCoord{{0, 0, 0}, {1, 1, 1}, {0, 0, 1}} {
+		if err := g.SetBoardMove(move, g.PlayerToMove()); err != nil {
+			t.Fatalf("move %v: %v", move, err)
+		}
+	}
+	if g.BoardDead(0) || g.BoardDead(1) {
+		t.Fatal("board dead without a line")
+	}
+
+	// the fourth cross completes the top row of board 0
+	if err := g.SetBoardMove(BoardCoord{0, 0, 2}, g.PlayerToMove()); err != nil {
+		t.Fatal(err)
+	}
+	if !g.BoardDead(0) || g.BoardDead(1) {
+		t.Fatalf("dead boards %v %v, want board 0 only", g.BoardDead(0), g.BoardDead(1))
+	}
+	if over, _ := g.IsOver(); over {
+		t.Fatal("game over with a live board")
+	}
+	if err := g.SetBoardMove(BoardCoord{0, 2, 2}, g.PlayerToMove()); err == nil {
+		t.Error("move on a dead board accepted")
+	}
+	for _, move := range g.LegalBoardMoves() {
+		if move.Board == 0 {
+			t.Fatalf("legal move %v on the dead board", move)
+		}
+	}
+
+	// Nought, the second player, kills the last board and loses
+	for _, move := range []BoardCoord{{1, 0, 0}, {1, 2, 2}} {
+		if err := g.SetBoardMove(move, g.PlayerToMove()); err != nil {
+			t.Fatalf("move %v: %v", move, err)
+		}
+	}
+	over, winner := g.IsOver()
+	if !over || winner != Cross {
+		t.Errorf("IsOver = %v, %v, want Cross to win", over, winner)
+	}
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Workers returns the number of goroutines a parallel search uses:
+// the given number, or GOMAXPROCS if it is not positive
+func Workers(workers int) int {
+	if workers < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// MinimaxMoves evaluates the root moves of Minimax concurrently on at most workers goroutines
+// (GOMAXPROCS if workers is not positive) and returns the best score with all moves reaching it
+// in the order of the board: the first of them is the move of Minimax(g, currentPlayer, 0).
+// Returns nil moves if the game is over.
+func MinimaxMoves(g *Game, currentPlayer Mark, workers int) (int, []Coord) {
+	if gameOver, winner := g.IsOver(); gameOver {
+		return CalculateWinPoints(winner, 0), nil
+	}
+
+	opponent := GetOpponent(currentPlayer)
+	moves := g.EmptyCells()
+	scores := splitRoot(g, moves, workers, func(sim *Game, c Coord) int {
+		sim.Grid[c.Row][c.Col] = currentPlayer
+		score, _ := Minimax(sim, opponent, 1)
+		sim.Grid[c.Row][c.Col] = Empty
+		return score
+	})
+
+	bestScore, _ := pickBest(currentPlayer, moves, scores)
+	var best []Coord
+	for i, score := range scores {
+		if score == bestScore {
+			best = append(best, moves[i])
+		}
+	}
+	return bestScore, best
+}
+
+// splitRoot evaluates every root move by search on at most workers goroutines.
+// Each goroutine works on its own copy of the game, so search may change the board
+// as long as it restores it. Scores are returned in the order of moves
+func splitRoot(g *Game, moves []Coord, workers int, search func(sim *Game, c Coord) int) []int {
+	scores := make([]int, len(moves))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(Workers(workers), len(moves)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sim := g.Clone()
+			for i := range jobs {
+				scores[i] = search(sim, moves[i])
+			}
+		}()
+	}
+
+	for i := range moves {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return scores
+}
+
+// pickBest returns the best score for the current player and the first move reaching it,
+// the same move a sequential search over moves in this order chooses
+func pickBest(currentPlayer Mark, moves []Coord, scores []int) (int, Coord) {
+	bestScore := GetBestScore(currentPlayer)
+	bestCoord := NoCoord
+	for i, score := range scores {
+		if UpdateBestScore(currentPlayer, score, &bestScore) {
+			bestCoord = moves[i]
+		}
+	}
+	return bestScore, bestCoord
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+// parallelPositions are the positions the parallel search is checked on, with the player to move
+var parallelPositions = []struct {
+	name   string
+	grid   Grid
+	player Mark
+	misere bool
+}{
+	{name: "empty", grid: Grid{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}, player: Cross},
+	{name: "corner opening", grid: Grid{{1, 0, 0}, {0, 0, 0}, {0, 0, 0}}, player: Nought},
+	{name: "win or block", grid: Grid{{1, 1, 0}, {2, 2, 0}, {0, 0, 0}}, player: Cross},
+	{name: "block", grid: Grid{{1, 1, 0}, {0, 2, 0}, {0, 0, 0}}, player: Nought},
+	{name: "fork", grid: Grid{{1, 0, 0}, {0, 2, 0}, {0, 0, 1}}, player: Nought},
+	{name: "misere", grid: Grid{{0, 0, 0}, {0, 1, 0}, {0, 0, 0}}, player: Nought, misere: true},
+}
+
+// parallelGame returns a game with the grid and the classic rules, misere if set
+func parallelGame(grid Grid, misere bool) *Game {
+	g := NewGame()
+	g.Grid = grid.Clone()
+	g.Rules.Misere = misere
+	return g
+}
+
+func TestMinimaxMovesMatchesMinimax(t *testing.T) {
+	for _, pos := range parallelPositions {
+		wantScore, wantMove := Minimax(parallelGame(pos.grid, pos.misere), pos.player, 0)
+
+		for _, workers := range []int{0, 1, 2, 4, 8} {
+			t.Run(fmt.Sprintf("%s/%d workers", pos.name, workers), func(t *testing.T) {
+				g := parallelGame(pos.grid, pos.misere)
+				score, moves := MinimaxMoves(g, pos.player, workers)
+
+				if score != wantScore {
+					t.Errorf("score = %d, want %d", score, wantScore)
+				}
+				if len(moves) == 0 || moves[0] != wantMove {
+					t.Errorf("moves = %v, want %v first", moves, wantMove)
+				}
+				if !g.Grid.Equal(pos.grid) {
+					t.Errorf("grid changed by the search: %v", g.Grid)
+				}
+			})
+		}
+	}
+}
+
+func TestMinimaxMovesAllOptimal(t *testing.T) {
+	g := parallelGame(Grid{{1, 0, 0}, {0, 0, 0}, {0, 0, 0}}, false)
+	_, moves := MinimaxMoves(g, Nought, 4)
+
+	for _, move := range moves {
+		g.Grid[move.Row][move.Col] = Nought
+		score, _ := Minimax(g, Cross, 1)
+		g.Grid[move.Row][move.Col] = Empty
+		if score != 0 {
+			t.Errorf("move %v scores %d, want a draw", move, score)
+		}
+	}
+	if want := []Coord{{Row: 1, Col: 1}}; len(moves) != len(want) || moves[0] != want[0] {
+		t.Errorf("moves = %v, want %v", moves, want)
+	}
+}
+
+func TestMinimaxMovesGameOver(t *testing.T) {
+	g := parallelGame(Grid{{1, 1, 1}, {2, 2, 0}, {0, 0, 0}}, false)
+	if _, moves := MinimaxMoves(g, Nought, 4); moves != nil {
+		t.Errorf("moves = %v, want none", moves)
+	}
+}
+
+func BenchmarkMinimaxMoves(b *testing.B) {
+	for _, workers := range []int{1, 2, 4, 8, 0} {
+		name := fmt.Sprintf("%d workers", workers)
+		if workers == 0 {
+			name = fmt.Sprintf("GOMAXPROCS %d workers", Workers(0))
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MinimaxMoves(NewGame(), Cross, workers)
+			}
+		})
+	}
+}
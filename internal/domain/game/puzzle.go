@@ -0,0 +1,318 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// PuzzleSize and PuzzleWinLength - the board of the generated puzzles
+const (
+	PuzzleSize      = 5
+	PuzzleWinLength = 4
+)
+
+// PuzzleDays is the number of days before and after today the daily puzzles are served for
+const PuzzleDays = 7
+
+// Bounds of the generated puzzles: the number of marks of each player in the layout,
+// the largest number of blocked cells and the number of positions tried before giving up
+const (
+	puzzleMinMarks   = 3
+	puzzleMaxMarks   = 6
+	puzzleMaxBlocked = 2
+	puzzleAttempts   = 20000
+)
+
+// dailyPuzzleMoves is the number of moves of the daily puzzle by the day of the week
+var dailyPuzzleMoves = [7]int{
+	time.Monday:    2,
+	time.Tuesday:   2,
+	time.Wednesday: 3,
+	time.Thursday:  3,
+	time.Friday:    3,
+	time.Saturday:  4,
+	time.Sunday:    4,
+}
+
+// Puzzle is a "win in N" position on a custom layout: X is to move and has a forced win
+// in Moves own moves, which can't be won faster and starts with only one move
+type Puzzle struct {
+	Rules    Rules
+	Layout   Grid    // Position to solve, blocked cells included
+	Moves    int     // Number of X moves of the forced win, the winning move included
+	Solution []Coord // Main line: the moves of X with the longest defence of O between them
+}
+
+// PuzzleCheck is the result of playing a submitted solution line of a puzzle
+type PuzzleCheck struct {
+	Solved bool
+	Line   []Coord // Moves played: the submitted ones with the replies of the defence between them
+	Reason string  // Why the line doesn't solve the puzzle, empty if it does
+}
+
+// puzzleSolver searches forced wins of one player by their own moves.
+// A move that leaves the opponent a winning cell is never searched, a win is forced
+// by two winning cells at once or by a winning cell that the opponent must block
+type puzzleSolver struct {
+	player   Mark
+	opponent Mark
+}
+
+// DailyPuzzle returns the puzzle of the day of the date in UTC: the generator is seeded by the day,
+// so the same day always gets the same puzzle, and the puzzles get longer during the week.
+// Returns an error if no puzzle was found
+func DailyPuzzle(date time.Time) (Puzzle, error) {
+	return GeneratePuzzle(rand.New(rand.NewSource(puzzleDay(date))), dailyPuzzleMoves[date.UTC().Weekday()])
+}
+
+// PuzzleServed reports whether the daily puzzle of the date is served on the day of now:
+// the days in UTC are at most PuzzleDays apart
+func PuzzleServed(date, now time.Time) bool {
+	days := puzzleDay(date) - puzzleDay(now)
+	return days >= -PuzzleDays && days <= PuzzleDays
+}
+
+// puzzleDay returns the number of the day of the date in UTC counted from the Unix epoch
+func puzzleDay(date time.Time) int64 {
+	y, m, d := date.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / int64(24*time.Hour/time.Second)
+}
+
+// GeneratePuzzle places random marks and blocked cells on the puzzle board until the position is
+// a puzzle with a forced win in the given number of X moves. Equal sequences of rnd give equal puzzles.
+// Returns an error if the number of moves is not positive or no puzzle was found
+func GeneratePuzzle(rnd RandomSource, moves int) (Puzzle, error) {
+	if moves < 1 {
+		return Puzzle{}, fmt.Errorf("puzzle must take at least one move")
+	}
+
+	rules := Rules{Size: PuzzleSize, WinLength: PuzzleWinLength, Variant: Classic, Custom: true}
+	for attempt := 0; attempt < puzzleAttempts; attempt++ {
+		layout := randomLayout(rnd, PuzzleSize)
+		g, err := NewGameFromLayout(rules, layout)
+		if err != nil {
+			continue
+		}
+		if solution, ok := solvePuzzle(g, moves); ok {
+			return Puzzle{Rules: g.Rules, Layout: layout, Moves: moves, Solution: solution}, nil
+		}
+	}
+	return Puzzle{}, fmt.Errorf("could not find a puzzle")
+}
+
+// randomLayout returns a grid of the size with a random number of blocked cells
+// and as many crosses as noughts in random cells
+func randomLayout(rnd RandomSource, size int) Grid {
+	layout := NewGrid(size)
+	marks := puzzleMinMarks + rnd.Intn(puzzleMaxMarks-puzzleMinMarks+1)
+	place := func(mark Mark, count int) {
+		for count > 0 {
+			row, col := rnd.Intn(size), rnd.Intn(size)
+			if layout[row][col] == Empty {
+				layout[row][col] = mark
+				count--
+			}
+		}
+	}
+
+	place(Blocked, rnd.Intn(puzzleMaxBlocked+1))
+	place(Cross, marks)
+	place(Nought, marks)
+	return layout
+}
+
+// solvePuzzle returns the main line of the forced win of X in the game in exactly the given number
+// of moves. The last value is false if there is no such win, a faster one or more than one first move
+func solvePuzzle(g *Game, moves int) ([]Coord, bool) {
+	s := puzzleSolver{player: Cross, opponent: Nought}
+	sim := g.Clone()
+	if moves > 1 && s.winsIn(sim, moves-1) {
+		return nil, false
+	}
+
+	first := NoCoord
+	for _, c := range s.candidates(sim) {
+		if s.forcesWin(sim, c, moves) {
+			if first != NoCoord {
+				return nil, false
+			}
+			first = c
+		}
+	}
+	if first == NoCoord {
+		return nil, false
+	}
+
+	line := []Coord{first}
+	sim.Grid[first.Row][first.Col] = Cross
+	for left := moves - 1; left > 0; left-- {
+		reply := s.defence(sim, left)
+		sim.Grid[reply.Row][reply.Col] = Nought
+		move := s.fastestMove(sim, left)
+		sim.Grid[move.Row][move.Col] = Cross
+		line = append(line, reply, move)
+	}
+	return line, true
+}
+
+// Check plays the submitted moves of X from the puzzle layout, answering each one with the longest
+// defence of O. The line solves the puzzle if X completes a line, which takes at most the puzzle moves
+// as every move has to keep the forced win. A line that ends before the win also gets the next reply,
+// so it can be continued
+func (p Puzzle) Check(moves []Coord) PuzzleCheck {
+	s := puzzleSolver{player: Cross, opponent: Nought}
+	g, err := NewGameFromLayout(p.Rules, p.Layout)
+	if err != nil {
+		return PuzzleCheck{Reason: err.Error()}
+	}
+
+	check := PuzzleCheck{}
+	for i, move := range moves {
+		left := p.Moves - i
+		if err := g.Clone().SetPlayerMove(move, Cross); err != nil {
+			check.Reason = fmt.Sprintf("move %d is not legal: %v", i+1, err)
+			return check
+		}
+		g.Grid[move.Row][move.Col] = Cross
+		check.Line = append(check.Line, move)
+
+		if g.completesLine(move) {
+			check.Solved = true
+			return check
+		}
+		escapes := len(g.winningCells(Nought)) > 0
+		g.Grid[move.Row][move.Col] = Empty
+		if escapes || !s.forcesWin(g, move, left) {
+			check.Reason = fmt.Sprintf("move %d doesn't keep the forced win", i+1)
+			return check
+		}
+		g.Grid[move.Row][move.Col] = Cross
+
+		reply := s.defence(g, left-1)
+		g.Grid[reply.Row][reply.Col] = Nought
+		check.Line = append(check.Line, reply)
+	}
+
+	check.Reason = "line ends before the win"
+	return check
+}
+
+// winsIn reports whether the player to move wins in at most n own moves against any defence
+func (s *puzzleSolver) winsIn(g *Game, n int) bool {
+	if n < 1 {
+		return false
+	}
+	if len(g.winningCells(s.player)) > 0 {
+		return true
+	}
+	if n == 1 {
+		return false
+	}
+
+	for _, c := range s.candidates(g) {
+		if s.forcesWin(g, c, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// candidates returns the moves worth searching for the player: the winning cells if there are any,
+// otherwise the moves that don't leave the opponent a winning cell, which is the only block of the
+// opponent's winning cell if there is one and none if there are two
+func (s *puzzleSolver) candidates(g *Game) []Coord {
+	if wins := g.winningCells(s.player); len(wins) > 0 {
+		return wins
+	}
+
+	blocks := g.winningCells(s.opponent)
+	switch len(blocks) {
+	case 0:
+		return g.EmptyCells()
+	case 1:
+		return blocks
+	default:
+		return nil
+	}
+}
+
+// forcesWin reports whether the move of the player in the empty cell c wins in at most n own moves
+// counting it, against any reply. The player has no winning cell before the move and the opponent
+// gets none by it
+func (s *puzzleSolver) forcesWin(g *Game, c Coord, n int) bool {
+	g.Grid[c.Row][c.Col] = s.player
+	defer func() { g.Grid[c.Row][c.Col] = Empty }()
+
+	if g.completesLine(c) {
+		return true
+	}
+	if n < 2 {
+		return false
+	}
+
+	threats := g.winningCells(s.player)
+	if len(threats) >= 2 {
+		return true
+	}
+	replies := threats
+	if len(replies) == 0 {
+		if n == 2 {
+			return false
+		}
+		replies = g.EmptyCells()
+	}
+	if len(replies) == 0 {
+		return false
+	}
+
+	for _, r := range replies {
+		g.Grid[r.Row][r.Col] = s.opponent
+		holds := !g.completesLine(r) && s.winsIn(g, n-1)
+		g.Grid[r.Row][r.Col] = Empty
+		if !holds {
+			return false
+		}
+	}
+	return true
+}
+
+// distance returns the smallest number of own moves up to n the player to move wins in, 0 if none
+func (s *puzzleSolver) distance(g *Game, n int) int {
+	for k := 1; k <= n; k++ {
+		if s.winsIn(g, k) {
+			return k
+		}
+	}
+	return 0
+}
+
+// defence returns the reply of the opponent, after which the player wins in at most n own moves,
+// that holds out longest: the first one in the order of the board among the equally long ones
+func (s *puzzleSolver) defence(g *Game, n int) Coord {
+	best, bestDistance := NoCoord, -1
+	for _, r := range g.EmptyCells() {
+		g.Grid[r.Row][r.Col] = s.opponent
+		d := n + 1
+		if !g.completesLine(r) {
+			d = s.distance(g, n)
+		}
+		g.Grid[r.Row][r.Col] = Empty
+		if d > bestDistance {
+			best, bestDistance = r, d
+		}
+	}
+	return best
+}
+
+// fastestMove returns the first move in the order of the board that wins in the fewest own moves up to n
+func (s *puzzleSolver) fastestMove(g *Game, n int) Coord {
+	for k := 1; k <= n; k++ {
+		for _, c := range s.candidates(g) {
+			if s.forcesWin(g, c, k) {
+				return c
+			}
+		}
+	}
+	return NoCoord
+}
@@ -0,0 +1,172 @@
+package game
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPuzzleServed(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 23, 30, 0, 0, time.UTC)
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{date: now, want: true},
+		{date: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC), want: true},
+		{date: now.AddDate(0, 0, -PuzzleDays), want: true},
+		{date: now.AddDate(0, 0, PuzzleDays), want: true},
+		{date: time.Date(2026, time.March, 17, 0, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2026, time.March, 18, 0, 0, 0, 0, time.UTC), want: false},
+		{date: now.AddDate(0, 0, -PuzzleDays-1), want: false},
+		{date: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := PuzzleServed(tt.date, now); got != tt.want {
+			t.Errorf("PuzzleServed(%v) = %v, want %v", tt.date.Format(time.DateOnly), got, tt.want)
+		}
+	}
+}
+
+func TestDailyPuzzleSolutions(t *testing.T) {
+	start := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+	for day := 0; day < 43; day++ {
+		date := start.AddDate(0, 0, day)
+		p, err := DailyPuzzle(date)
+		if err != nil {
+			t.Fatalf("%v: %v", date.Format(time.DateOnly), err)
+		}
+		if want := dailyPuzzleMoves[date.Weekday()]; p.Moves != want || len(p.Solution) != 2*want-1 {
+			t.Fatalf("%v: %d moves with a solution of %d, want %d moves", date.Format(time.DateOnly), p.Moves, len(p.Solution), want)
+		}
+
+		var moves []Coord
+		for i := 0; i < len(p.Solution); i += 2 {
+			moves = append(moves, p.Solution[i])
+		}
+		if check := p.Check(moves); !check.Solved || !coordsEqual(check.Line, p.Solution) {
+			t.Errorf("%v: solution %v checks as %+v", date.Format(time.DateOnly), moves, check)
+		}
+		for n := 0; n < len(moves); n++ {
+			check := p.Check(moves[:n])
+			if check.Solved || check.Reason != "line ends before the win" || !coordsEqual(check.Line, p.Solution[:2*n]) {
+				t.Errorf("%v: prefix %v checks as %+v", date.Format(time.DateOnly), moves[:n], check)
+			}
+		}
+	}
+}
+
+func TestDailyPuzzleSameDay(t *testing.T) {
+	morning, err := DailyPuzzle(time.Date(2026, time.March, 10, 1, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	evening, err := DailyPuzzle(time.Date(2026, time.March, 10, 23, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(morning, evening) {
+		t.Error("puzzles of the same day differ")
+	}
+}
+
+func TestGeneratePuzzleMoves(t *testing.T) {
+	if _, err := GeneratePuzzle(rand.New(rand.NewSource(1)), 0); err == nil {
+		t.Error("puzzle of no moves was generated")
+	}
+}
+
+func TestPuzzleCheckWrongMove(t *testing.T) {
+	p, err := GeneratePuzzle(rand.New(rand.NewSource(1)), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGameFromLayout(p.Rules, p.Layout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range g.EmptyCells() {
+		if c == p.Solution[0] {
+			continue
+		}
+		if check := p.Check([]Coord{c}); check.Solved || check.Reason != "move 1 doesn't keep the forced win" {
+			t.Errorf("first move %v checks as %+v", c, check)
+		}
+	}
+
+	for row := range p.Layout {
+		for col, mark := range p.Layout[row] {
+			if mark == Empty {
+				continue
+			}
+			if check := p.Check([]Coord{{Row: row, Col: col}}); check.Solved || check.Reason == "" {
+				t.Errorf("move onto the mark at (%d, %d) checks as %+v", row, col, check)
+			}
+		}
+	}
+}
+
+func TestSolvePuzzleUnique(t *testing.T) {
+	rules := Rules{Size: PuzzleSize, WinLength: PuzzleWinLength, Variant: Classic, Custom: true}
+	tests := []struct {
+		name   string
+		layout Grid
+		moves  int
+		want   []Coord
+	}{
+		{
+			name: "one winning move",
+			layout: Grid{
+				{1, 1, 1, 0, 2},
+				{0, 0, 2, 0, 0},
+				{1, 1, 0, 2, 0},
+				{2, 0, 0, 0, 0},
+				{2, 0, 1, 0, 2},
+			},
+			moves: 1,
+			want:  []Coord{{Row: 0, Col: 3}},
+		},
+		{
+			name: "two winning moves",
+			layout: Grid{
+				{1, 1, 1, 0, 2},
+				{0, 0, 0, 0, 0},
+				{1, 1, 1, 0, 2},
+				{2, 0, 2, 0, 0},
+				{2, 0, 0, 0, 2},
+			},
+			moves: 1,
+		},
+		{
+			name: "faster win",
+			layout: Grid{
+				{1, 1, 1, 0, 2},
+				{0, 0, 2, 0, 0},
+				{1, 1, 0, 2, 0},
+				{2, 0, 0, 0, 0},
+				{2, 0, 1, 0, 2},
+			},
+			moves: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameFromLayout(rules, tt.layout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			line, ok := solvePuzzle(g, tt.moves)
+			if ok != (tt.want != nil) || !coordsEqual(line, tt.want) {
+				t.Errorf("solvePuzzle = %v, %v, want %v", line, ok, tt.want)
+			}
+			if !g.Grid.Equal(tt.layout) {
+				t.Errorf("grid changed by the search: %v", g.Grid)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package game
+
+import (
+	"context"
+	"math/bits"
+)
+
+// QubicSize is the number of layers, rows and cols of the qubic cube
+const QubicSize = 4
+
+// qubicSearchDepth is the largest number of own threats in a forced win found by QubicMove
+const qubicSearchDepth = 8
+
+// Weights of the lines through a cell of the qubic cube by the number of marks of one player in them,
+// see qubicScore
+var qubicLineWeights = [QubicSize]float64{1, 8, 64, threatWin}
+
+// qubicLines are the masks of the 76 winning lines of the cube, qubicCellLines the lines through every cell.
+// Bit layer*QubicSize*QubicSize+row*QubicSize+col of a mask stands for the cell
+var qubicLines, qubicCellLines = qubicLineTable()
+
+// qubicSearcher keeps the state of one threat-space search of QubicMove
+type qubicSearcher struct {
+	ctx     context.Context
+	nodes   int
+	aborted bool
+}
+
+// qubicLineTable returns the masks of all lines of QubicSize cells in a row of the cube
+// in the 13 directions, and the indexes of the lines through every cell
+func qubicLineTable() ([]uint64, [QubicSize * QubicSize * QubicSize][]int) {
+	var lines []uint64
+	var cellLines [QubicSize * QubicSize * QubicSize][]int
+
+	inside := func(v int) bool { return v >= 0 && v < QubicSize }
+	for dl := -1; dl <= 1; dl++ {
+		for dr := -1; dr <= 1; dr++ {
+			for dc := -1; dc <= 1; dc++ {
+				// every line is taken in one direction only: the first non-zero step is positive
+				if dl < 0 || (dl == 0 && dr < 0) || (dl == 0 && dr == 0 && dc <= 0) {
+					continue
+				}
+
+				for l := 0; l < QubicSize; l++ {
+					for r := 0; r < QubicSize; r++ {
+						for c := 0; c < QubicSize; c++ {
+							last := QubicSize - 1
+							if !inside(l+dl*last) || !inside(r+dr*last) || !inside(c+dc*last) {
+								continue
+							}
+
+							var line uint64
+							for k := 0; k < QubicSize; k++ {
+								line |= 1 << qubicCell(l+dl*k, r+dr*k, c+dc*k)
+							}
+							for k := 0; k < QubicSize; k++ {
+								cell := qubicCell(l+dl*k, r+dr*k, c+dc*k)
+								cellLines[cell] = append(cellLines[cell], len(lines))
+							}
+							lines = append(lines, line)
+						}
+					}
+				}
+			}
+		}
+	}
+	return lines, cellLines
+}
+
+// qubicCell returns the bit index of the cell of the cube
+func qubicCell(layer, row, col int) int {
+	return (layer*QubicSize+row)*QubicSize + col
+}
+
+// qubicCoord converts the bit index of the cell back into a BoardCoord with the layer as the board
+func qubicCoord(cell int) BoardCoord {
+	return BoardCoord{
+		Board: cell / (QubicSize * QubicSize),
+		Row:   cell / QubicSize % QubicSize,
+		Col:   cell % QubicSize,
+	}
+}
+
+// qubicMasks returns the masks of the cells of the player and of the opponent
+func (g *Game) qubicMasks(player Mark) (uint64, uint64) {
+	var own, opponent uint64
+	for l, layer := range g.Boards {
+		for i := range layer {
+			for j := range layer[i] {
+				switch layer[i][j] {
+				case player:
+					own |= 1 << qubicCell(l, i, j)
+				case GetOpponent(player):
+					opponent |= 1 << qubicCell(l, i, j)
+				}
+			}
+		}
+	}
+	return own, opponent
+}
+
+// qubicOver reports the winner of a qubic game by the line table, or a draw when the cube is full
+func (g *Game) qubicOver() (bool, Mark) {
+	crosses, noughts := g.qubicMasks(Cross)
+	for _, line := range qubicLines {
+		if crosses&line == line {
+			return true, Cross
+		}
+		if noughts&line == line {
+			return true, Nought
+		}
+	}
+	return bits.OnesCount64(crosses|noughts) == len(qubicCellLines), Empty
+}
+
+// QubicMove returns a move for the current player in a qubic game and the number of plies of the forced win
+// it starts, 0 if it doesn't start one. A win comes first, then blocking a win. Then the threat-space search
+// looks for a forced win: a chain of at most qubicSearchDepth threats, each answered by the only block,
+// that ends in two threats at once. A forced win found for the opponent is spoiled by taking its first cell.
+// Otherwise the cell with the best lines for the player and against the opponent is chosen.
+// The searches stop when ctx is done. Returns NoBoardCoord if the game is over
+func QubicMove(ctx context.Context, g *Game, currentPlayer Mark) (BoardCoord, int) {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return NoBoardCoord, 0
+	}
+
+	own, opponent := g.qubicMasks(currentPlayer)
+	if cell := qubicWinningCell(own, opponent); cell >= 0 {
+		return qubicCoord(cell), 1
+	}
+	if cell := qubicWinningCell(opponent, own); cell >= 0 {
+		return qubicCoord(cell), 0
+	}
+
+	s := qubicSearcher{ctx: ctx}
+	for depth := 1; depth <= qubicSearchDepth && !s.aborted; depth++ {
+		if cell := s.forcedWin(own, opponent, depth); cell >= 0 {
+			return qubicCoord(cell), 2*depth + 1
+		}
+	}
+
+	s = qubicSearcher{ctx: ctx}
+	for depth := 1; depth <= qubicSearchDepth && !s.aborted; depth++ {
+		if cell := s.forcedWin(opponent, own, depth); cell >= 0 {
+			return qubicCoord(cell), 0
+		}
+	}
+
+	best, bestScore := -1, -1.0
+	for empty := qubicEmpty(own, opponent); empty != 0; empty &= empty - 1 {
+		cell := bits.TrailingZeros64(empty)
+		score := qubicScore(cell, own, opponent) + defenceFraction*qubicScore(cell, opponent, own)
+		if score > bestScore {
+			best, bestScore = cell, score
+		}
+	}
+	return qubicCoord(best), 0
+}
+
+// qubicEmpty returns the mask of the empty cells of the cube
+func qubicEmpty(own, opponent uint64) uint64 {
+	return ^(own | opponent)
+}
+
+// qubicScore sums the weights of the lines through the cell that the opponent hasn't blocked
+// by the number of marks of the player in them
+func qubicScore(cell int, own, opponent uint64) float64 {
+	score := 0.0
+	for _, i := range qubicCellLines[cell] {
+		if qubicLines[i]&opponent == 0 {
+			score += qubicLineWeights[bits.OnesCount64(qubicLines[i]&own)]
+		}
+	}
+	return score
+}
+
+// qubicThreats returns the mask of the empty cells that complete a line of the player
+func qubicThreats(own, opponent uint64) uint64 {
+	var threats uint64
+	for _, line := range qubicLines {
+		if line&opponent == 0 && bits.OnesCount64(line&own) == QubicSize-1 {
+			threats |= line &^ own
+		}
+	}
+	return threats
+}
+
+// qubicWinningCell returns the first empty cell that completes a line of the player, or -1 if there is none
+func qubicWinningCell(own, opponent uint64) int {
+	if threats := qubicThreats(own, opponent); threats != 0 {
+		return bits.TrailingZeros64(threats)
+	}
+	return -1
+}
+
+// forcedWin returns the first cell of a forced win of the player with at most depth threats, or -1.
+// The opponent has no threat of their own: a move making two threats wins, a move making one forces
+// the block, which is only followed if it doesn't give the opponent a threat
+func (s *qubicSearcher) forcedWin(own, opponent uint64, depth int) int {
+	s.nodes++
+	if s.nodes%1024 == 0 && s.ctx.Err() != nil {
+		s.aborted = true
+	}
+	if s.aborted || depth == 0 {
+		return -1
+	}
+
+	for empty := qubicEmpty(own, opponent); empty != 0; empty &= empty - 1 {
+		cell := bits.TrailingZeros64(empty)
+		next := own | 1<<cell
+		threats := qubicThreats(next, opponent)
+		switch bits.OnesCount64(threats) {
+		case 0:
+			continue
+		case 1:
+			block := opponent | threats
+			if qubicThreats(block, next) != 0 {
+				continue
+			}
+			if s.forcedWin(next, block, depth-1) >= 0 {
+				return cell
+			}
+		default:
+			return cell
+		}
+	}
+	return -1
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestQubicLineTable(t *testing.T) {
+	// every line from every cell in all 26 directions, each found from both ends
+	want := map[uint64]bool{}
+	for cell := 0; cell < QubicSize*QubicSize*QubicSize; cell++ {
+		c := qubicCoord(cell)
+		for dl := -1; dl <= 1; dl++ {
+			for dr := -1; dr <= 1; dr++ {
+				for dc := -1; dc <= 1; dc++ {
+					var line uint64
+					for k := 0; k < QubicSize; k++ {
+						l, r, col := c.Board+dl*k, c.Row+dr*k, c.Col+dc*k
+						if l < 0 || l >= QubicSize || r < 0 || r >= QubicSize || col < 0 || col >= QubicSize {
+							line = 0
+							break
+						}
+						line |= 1 << qubicCell(l, r, col)
+					}
+					if bits.OnesCount64(line) == QubicSize {
+						want[line] = true
+					}
+				}
+			}
+		}
+	}
+
+	if len(qubicLines) != 76 || len(want) != 76 {
+		t.Fatalf("%d lines in the table, %d found, want 76", len(qubicLines), len(want))
+	}
+	seen := map[uint64]bool{}
+	for _, line := range qubicLines {
+		if !want[line] || seen[line] {
+			t.Errorf("line %064b is not a line of the cube or repeated", line)
+		}
+		seen[line] = true
+	}
+
+	// the 8 corners and the 8 inner cells lie on 7 lines, the other 48 cells on 4
+	entries := 0
+	for cell, lines := range qubicCellLines {
+		entries += len(lines)
+		for _, i := range lines {
+			if qubicLines[i]&(1<<cell) == 0 {
+				t.Errorf("line %d listed for cell %d doesn't pass it", i, cell)
+			}
+		}
+	}
+	if entries != 304 {
+		t.Errorf("%d cell-line entries, want 304", entries)
+	}
+}
+
+func TestQubicOver(t *testing.T) {
+	tests := []struct {
+		name   string
+		cells  []BoardCoord
+		mark   Mark
+		over   bool
+		winner Mark
+	}{
+		{name: "three in a row", cells: []BoardCoord{{0, 0, 0}, {0, 0, 1}, {0, 0, 2}}, mark: Cross},
+		{name: "row", cells: []BoardCoord{{2, 1, 0}, {2, 1, 1}, {2, 1, 2}, {2, 1, 3}}, mark: Cross, over: true, winner: Cross},
+		{name: "column across layers", cells: []BoardCoord{{0, 3, 2}, {1, 3, 2}, {2, 3, 2}, {3, 3, 2}}, mark: Nought, over: true, winner: Nought},
+		{name: "space diagonal", cells: []BoardCoord{{0, 0, 3}, {1, 1, 2}, {2, 2, 1}, {3, 3, 0}}, mark: Nought, over: true, winner: Nought},
+		{name: "broken diagonal", cells: []BoardCoord{{0, 0, 1}, {1, 1, 2}, {2, 2, 3}, {3, 3, 0}}, mark: Cross},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameWithRules(Qubic.Rules())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.cells {
+				g.Boards[c.Board][c.Row][c.Col] = tt.mark
+			}
+			if over, winner := g.IsOver(); over != tt.over || winner != tt.winner {
+				t.Errorf("IsOver = %v, %v, want %v, %v", over, winner, tt.over, tt.winner)
+			}
+		})
+	}
+}
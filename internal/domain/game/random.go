@@ -0,0 +1,35 @@
+package game
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RandomSource chooses among equally good moves. *rand.Rand satisfies it,
+// a source shared by goroutines has to be safe for concurrent use
+type RandomSource interface {
+	Intn(n int) int
+}
+
+// lockedSource is a RandomSource safe for concurrent use
+type lockedSource struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewRandomSource creates a RandomSource safe for concurrent use.
+// Equal seeds give equal sequences, seed 0 seeds the source from the clock
+func NewRandomSource(seed int64) RandomSource {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &lockedSource{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// Intn returns a random number in [0, n)
+func (s *lockedSource) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Intn(n)
+}
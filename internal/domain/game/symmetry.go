@@ -0,0 +1,134 @@
+package game
+
+import "fmt"
+
+// Symmetry is one of the eight rotations and reflections of a square board
+// (the dihedral group of the square). Positions that map to each other by
+// a symmetry are equivalent for the game.
+type Symmetry int
+
+// Constants representing the board symmetries.
+// Reflections are defined as the horizontal flip followed by a clockwise rotation.
+const (
+	Identity         Symmetry = iota // board is left as is
+	Rotate90                         // clockwise quarter turn
+	Rotate180                        // half turn
+	Rotate270                        // counterclockwise quarter turn
+	FlipHorizontal                   // mirror left to right
+	FlipAntiDiagonal                 // mirror over the anti-diagonal
+	FlipVertical                     // mirror top to bottom
+	FlipDiagonal                     // mirror over the main diagonal
+)
+
+// SymmetryCount is the number of board symmetries
+const SymmetryCount = 8
+
+// MapCoord returns the coordinate the given cell of a board with size rows and cols moves to under the symmetry
+func (s Symmetry) MapCoord(c Coord, size int) Coord {
+	last := size - 1
+	if s >= FlipHorizontal {
+		c = Coord{Row: c.Row, Col: last - c.Col}
+	}
+	for i := 0; i < int(s)%4; i++ {
+		c = Coord{Row: c.Col, Col: last - c.Row}
+	}
+	return c
+}
+
+// Inverse returns the symmetry that undoes s
+func (s Symmetry) Inverse() Symmetry {
+	if s >= FlipHorizontal {
+		return s
+	}
+	return (4 - s) % 4
+}
+
+// Apply returns the image of the grid under the symmetry
+func (s Symmetry) Apply(grid Grid) Grid {
+	image := NewGrid(len(grid))
+	for i := range grid {
+		for j := range grid[i] {
+			c := s.MapCoord(Coord{Row: i, Col: j}, len(grid))
+			image[c.Row][c.Col] = grid[i][j]
+		}
+	}
+	return image
+}
+
+// Canonical returns the representative of all standard grids equivalent to the given one
+// and the symmetry that maps the grid to it: the grid of CanonicalBitboard, so opening analytics
+// can group equivalent positions by either of them. Panics like Bitboard if the grid is not standard
+func (grid Grid) Canonical() (Grid, Symmetry) {
+	key, s := grid.CanonicalBitboard()
+	return key.Grid(), s
+}
+
+// CanonicalBitboard returns the smallest bitboard among all symmetric images of the standard grid
+// and the symmetry that maps the grid to that image. Panics like Bitboard if the grid is not standard
+func (grid Grid) CanonicalBitboard() (Bitboard, Symmetry) {
+	best, bestSym := grid.Bitboard(), Identity
+	for s := Rotate90; s < SymmetryCount; s++ {
+		if b := s.Apply(grid).Bitboard(); b < best {
+			best, bestSym = b, s
+		}
+	}
+	return best, bestSym
+}
+
+// Bitboard is a compact encoding of a standard GridSize grid usable as a map key:
+// bit row*GridSize+col of the low half is set for crosses and the same bit of the high half for noughts
+type Bitboard uint32
+
+// noughtShift is the offset of the noughts half in a Bitboard
+const noughtShift = 16
+
+// Bitboard encodes the standard grid into a Bitboard.
+// Panics if the grid is not GridSize x GridSize: other grids don't fit, so encoding one is a caller bug
+func (grid Grid) Bitboard() Bitboard {
+	if !grid.isStandardSize() {
+		panic(fmt.Sprintf("bitboard of a grid with %d rows: only %dx%d grids are encoded", len(grid), GridSize, GridSize))
+	}
+
+	var b Bitboard
+	for i := 0; i < GridSize; i++ {
+		for j := 0; j < GridSize; j++ {
+			bit := Bitboard(1) << (i*GridSize + j)
+			switch grid[i][j] {
+			case Cross:
+				b |= bit
+			case Nought:
+				b |= bit << noughtShift
+			}
+		}
+	}
+	return b
+}
+
+// isStandardSize reports whether the grid has GridSize rows of GridSize cells
+func (grid Grid) isStandardSize() bool {
+	if len(grid) != GridSize {
+		return false
+	}
+	for _, row := range grid {
+		if len(row) != GridSize {
+			return false
+		}
+	}
+	return true
+}
+
+// Grid decodes the Bitboard back into a standard grid
+func (b Bitboard) Grid() Grid {
+	grid := NewGrid(GridSize)
+	for i := 0; i < GridSize; i++ {
+		for j := 0; j < GridSize; j++ {
+			bit := Bitboard(1) << (i*GridSize + j)
+			if b&bit != 0 {
+				grid[i][j] = Cross
+			} else if b&(bit<<noughtShift) != 0 {
+				grid[i][j] = Nought
+			}
+		}
+	}
+	return grid
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+// symmetryGrids are asymmetric grids, so every symmetry gives a different image
+var symmetryGrids = []Grid{
+	{{1, 2, 0}, {0, 1, 0}, {0, 0, 2}},
+	{{0, 1, 0}, {0, 0, 2}, {0, 0, 0}},
+	{{1, 0, 0, 2}, {0, 2, 1, 0}, {3, 0, 0, 0}, {0, 0, 0, 1}},
+}
+
+func TestSymmetryInverse(t *testing.T) {
+	for _, grid := range symmetryGrids {
+		images := map[string]Symmetry{}
+		for s := Identity; s < SymmetryCount; s++ {
+			image := s.Apply(grid)
+			if back := s.Inverse().Apply(image); !back.Equal(grid) {
+				t.Errorf("symmetry %d: inverse of %v gives %v, want %v", s, image, back, grid)
+			}
+
+			key := fmt.Sprint(image)
+			if other, ok := images[key]; ok {
+				t.Errorf("symmetries %d and %d give the same image %v", other, s, image)
+			}
+			images[key] = s
+
+			for i := range grid {
+				for j := range grid[i] {
+					c := Coord{Row: i, Col: j}
+					if back := s.Inverse().MapCoord(s.MapCoord(c, len(grid)), len(grid)); back != c {
+						t.Errorf("symmetry %d: inverse maps %v back to %v", s, c, back)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCanonicalOrbit(t *testing.T) {
+	for _, grid := range symmetryGrids[:2] {
+		want, wantSym := grid.Canonical()
+		if image := wantSym.Apply(grid); !image.Equal(want) {
+			t.Errorf("symmetry %d maps %v to %v, want the canonical %v", wantSym, grid, image, want)
+		}
+
+		for s := Identity; s < SymmetryCount; s++ {
+			image := s.Apply(grid)
+			canonical, sym := image.Canonical()
+			if !canonical.Equal(want) {
+				t.Errorf("canonical of image %d is %v, want %v", s, canonical, want)
+			}
+			if mapped := sym.Apply(image); !mapped.Equal(canonical) {
+				t.Errorf("symmetry %d maps image %d to %v, want %v", sym, s, mapped, canonical)
+			}
+		}
+	}
+}
+
+func TestBitboardRoundTrip(t *testing.T) {
+	for _, grid := range symmetryGrids[:2] {
+		if back := grid.Bitboard().Grid(); !back.Equal(grid) {
+			t.Errorf("bitboard of %v decodes to %v", grid, back)
+		}
+	}
+}
+
+func TestBitboardNotStandard(t *testing.T) {
+	grids := []Grid{NewGrid(2), NewGrid(4), {{0, 0, 0}, {0, 0}, {0, 0, 0}}}
+	for _, grid := range grids {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("bitboard of %v didn't panic", grid)
+				}
+			}()
+			grid.CanonicalBitboard()
+		}()
+	}
+}
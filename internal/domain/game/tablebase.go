@@ -0,0 +1,219 @@
+package game
+
+//go:generate go run ../../../cmd/tablegen -o tablebase.bin
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Outcome is the result of a position under perfect play of both sides
+type Outcome int
+
+// Constants representing the possible outcomes stored in the tablebase
+const (
+	Draw       Outcome = iota // neither side can force a win
+	CrossWins                 // Cross wins with perfect play
+	NoughtWins                // Nought wins with perfect play
+)
+
+// tablebaseMagic marks the beginning of the tablebase binary format
+var tablebaseMagic = []byte("TTTB")
+
+// tablebaseEntrySize is the size of a single encoded entry: 4 bytes of key and 2 bytes of value
+const tablebaseEntrySize = 6
+
+// tablebaseOutcomeShift is the position of the outcome in the value of an entry, below it are
+// the bits of the optimal cells
+const tablebaseOutcomeShift = 12
+
+//go:embed tablebase.bin
+var tablebaseData []byte
+
+// tablebaseEntry is the stored optimal moves and outcome of a canonical position.
+// Bit i of moves is set if the cell with row-major index i is optimal
+type tablebaseEntry struct {
+	moves   uint16
+	outcome Outcome
+}
+
+var (
+	tablebaseOnce sync.Once
+	tablebase     map[Bitboard]tablebaseEntry
+)
+
+// LookupTablebase returns the perfect-play move and the outcome for the given player
+// from the precomputed tablebase: the first of TablebaseMoves, the move Minimax chooses.
+// The last value is false if the position is not covered by the table
+func LookupTablebase(g *Game, currentPlayer Mark) (Coord, Outcome, bool) {
+	moves, outcome, ok := TablebaseMoves(g, currentPlayer)
+	if !ok {
+		return NoCoord, Draw, false
+	}
+	return moves[0], outcome, true
+}
+
+// TablebaseMoves returns all moves with the best Minimax score for the given player in the order
+// of the board and the outcome from the precomputed tablebase. The last value is false if the position
+// is not covered by the table: the board is not standard, the game is over, the board is
+// unreachable in a game started by Cross or it is not the given player's turn.
+// Panics if the embedded table can't be decoded: it is generated at build time, so it is a build bug
+func TablebaseMoves(g *Game, currentPlayer Mark) ([]Coord, Outcome, bool) {
+	tablebaseOnce.Do(func() {
+		var err error
+		if tablebase, err = decodeTablebase(tablebaseData); err != nil {
+			panic(fmt.Sprintf("embedded tablebase: %v", err))
+		}
+	})
+
+	if !g.Rules.IsStandard() || sideToMove(g.Grid) != currentPlayer {
+		return nil, Draw, false
+	}
+
+	key, sym := g.Grid.CanonicalBitboard()
+	entry, ok := tablebase[key]
+	if !ok {
+		return nil, Draw, false
+	}
+
+	inverse := sym.Inverse()
+	var moves []Coord
+	for cell := 0; cell < GridSize*GridSize; cell++ {
+		if entry.moves&(1<<cell) != 0 {
+			moves = append(moves, inverse.MapCoord(Coord{Row: cell / GridSize, Col: cell % GridSize}, GridSize))
+		}
+	}
+	sortCoords(moves)
+	return moves, entry.outcome, true
+}
+
+// BuildTablebase enumerates all positions reachable from the empty board in a game
+// started by Cross, reduces them by the board symmetries, solves every non-terminal
+// position with Minimax, keeping all optimal moves, and returns the encoded table.
+func BuildTablebase() []byte {
+	entries := map[Bitboard]tablebaseEntry{}
+	visited := map[Bitboard]bool{}
+
+	var walk func(g *Game, currentPlayer Mark)
+	walk = func(g *Game, currentPlayer Mark) {
+		key, _ := g.Grid.CanonicalBitboard()
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+
+		if gameOver, _ := g.IsOver(); gameOver {
+			return
+		}
+
+		canonical := Game{Grid: key.Grid(), Rules: DefaultRules()}
+		score, moves := MinimaxMoves(&canonical, currentPlayer, 0)
+		entry := tablebaseEntry{outcome: scoreOutcome(score)}
+		for _, move := range moves {
+			entry.moves |= 1 << (move.Row*GridSize + move.Col)
+		}
+		entries[key] = entry
+
+		for i := 0; i < GridSize; i++ {
+			for j := 0; j < GridSize; j++ {
+				if g.Grid[i][j] == Empty {
+					g.Grid[i][j] = currentPlayer
+					walk(g, GetOpponent(currentPlayer))
+					g.Grid[i][j] = Empty
+				}
+			}
+		}
+	}
+	walk(&Game{Grid: NewGrid(GridSize), Rules: DefaultRules()}, Cross)
+
+	return encodeTablebase(entries)
+}
+
+// encodeTablebase serializes entries sorted by key.
+// Each entry holds the key and two bytes with the bits of the optimal cells in the low bits
+// and the outcome in the high bits.
+func encodeTablebase(entries map[Bitboard]tablebaseEntry) []byte {
+	keys := make([]Bitboard, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var buf bytes.Buffer
+	buf.Write(tablebaseMagic)
+	binary.Write(&buf, binary.LittleEndian, uint32(len(keys)))
+
+	for _, key := range keys {
+		entry := entries[key]
+		binary.Write(&buf, binary.LittleEndian, uint32(key))
+		binary.Write(&buf, binary.LittleEndian, entry.moves|uint16(entry.outcome)<<tablebaseOutcomeShift)
+	}
+
+	return buf.Bytes()
+}
+
+// decodeTablebase parses the binary table produced by encodeTablebase
+func decodeTablebase(data []byte) (map[Bitboard]tablebaseEntry, error) {
+	header := len(tablebaseMagic) + 4
+	if len(data) < header || !bytes.Equal(data[:len(tablebaseMagic)], tablebaseMagic) {
+		return nil, fmt.Errorf("invalid tablebase header")
+	}
+
+	count := int(binary.LittleEndian.Uint32(data[len(tablebaseMagic):header]))
+	if len(data) != header+count*tablebaseEntrySize {
+		return nil, fmt.Errorf("invalid tablebase size")
+	}
+
+	entries := make(map[Bitboard]tablebaseEntry, count)
+	for i := 0; i < count; i++ {
+		offset := header + i*tablebaseEntrySize
+		key := Bitboard(binary.LittleEndian.Uint32(data[offset:]))
+		value := binary.LittleEndian.Uint16(data[offset+4:])
+		moves := value & (1<<tablebaseOutcomeShift - 1)
+		if moves == 0 {
+			return nil, fmt.Errorf("tablebase entry %d has no moves", i)
+		}
+		entries[key] = tablebaseEntry{moves: moves, outcome: Outcome(value >> tablebaseOutcomeShift)}
+	}
+
+	return entries, nil
+}
+
+// scoreOutcome converts a Minimax score into an Outcome
+func scoreOutcome(score int) Outcome {
+	if score > 0 {
+		return CrossWins
+	} else if score < 0 {
+		return NoughtWins
+	}
+	return Draw
+}
+
+// sideToMove returns the mark that moves next in a game started by Cross,
+// or Empty if the marks count is not possible in such a game
+func sideToMove(grid Grid) Mark {
+	crosses, noughts := 0, 0
+	for i := range grid {
+		for j := range grid[i] {
+			switch grid[i][j] {
+			case Cross:
+				crosses++
+			case Nought:
+				noughts++
+			}
+		}
+	}
+
+	switch crosses - noughts {
+	case 0:
+		return Cross
+	case 1:
+		return Nought
+	default:
+		return Empty
+	}
+}
@@ -0,0 +1,80 @@
+package game
+
+import "testing"
+
+func TestTablebaseMovesMatchMinimax(t *testing.T) {
+	grids := []Grid{
+		{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+		{{0, 1, 0}, {0, 0, 0}, {0, 0, 0}},
+		{{1, 0, 0}, {0, 2, 0}, {0, 0, 1}},
+		{{1, 1, 0}, {2, 2, 0}, {1, 0, 0}},
+		{{2, 1, 0}, {0, 1, 0}, {0, 0, 0}},
+	}
+
+	for _, grid := range grids {
+		g := parallelGame(grid, false)
+		player := sideToMove(grid)
+
+		moves, outcome, ok := TablebaseMoves(g, player)
+		if !ok {
+			t.Fatalf("%v: position not covered", grid)
+		}
+		score, want := MinimaxMoves(g, player, 1)
+		if !coordsEqual(moves, want) || outcome != scoreOutcome(score) {
+			t.Errorf("%v: moves %v, outcome %d, want %v, %d", grid, moves, outcome, want, scoreOutcome(score))
+		}
+
+		_, first := Minimax(g, player, 0)
+		if move, _, _ := LookupTablebase(g, player); move != first {
+			t.Errorf("%v: LookupTablebase = %v, want %v", grid, move, first)
+		}
+	}
+}
+
+func TestTablebaseNotCovered(t *testing.T) {
+	g := parallelGame(Grid{{1, 0, 0}, {0, 0, 0}, {0, 0, 0}}, false)
+	if _, _, ok := TablebaseMoves(g, Cross); ok {
+		t.Error("position covered for the player not to move")
+	}
+
+	g.Rules.Misere = true
+	if _, _, ok := TablebaseMoves(g, Nought); ok {
+		t.Error("position covered for the misere rule")
+	}
+}
+
+func TestDecodeTablebase(t *testing.T) {
+	entries, err := decodeTablebase(BuildTablebase())
+	if err != nil {
+		t.Fatalf("decodeTablebase: %v", err)
+	}
+	embedded, err := decodeTablebase(tablebaseData)
+	if err != nil {
+		t.Fatalf("embedded tablebase: %v", err)
+	}
+	if len(entries) != len(embedded) {
+		t.Fatalf("built %d entries, embedded %d: run make generate", len(entries), len(embedded))
+	}
+	for key, entry := range entries {
+		if embedded[key] != entry {
+			t.Fatalf("entry of %v differs from the built one: run make generate", key.Grid())
+		}
+	}
+
+	if _, err := decodeTablebase(tablebaseData[:len(tablebaseData)-1]); err == nil {
+		t.Error("truncated tablebase decoded")
+	}
+}
+
+// coordsEqual reports whether the coordinates are equal in the same order
+func coordsEqual(a, b []Coord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
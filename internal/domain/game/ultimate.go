@@ -0,0 +1,189 @@
+package game
+
+import "math/rand"
+
+// UltimateSize is the number of rows and cols of the ultimate board: 3x3 small boards of 3x3 cells
+const UltimateSize = GridSize * GridSize
+
+// UltimateState is the position of an ultimate game on the level of small boards,
+// derived from the grid and the last move
+type UltimateState struct {
+	Winners   [GridSize][GridSize]Mark // Winners of the small boards, Empty for undecided and drawn ones
+	Closed    [GridSize][GridSize]bool // Small boards that are won or full and can't be played any more
+	NextBoard Coord                    // Small board the next move has to be made in, NoCoord for any open one
+}
+
+// Ultimate returns the state of the small boards of an ultimate game
+func (g *Game) Ultimate() UltimateState {
+	var s UltimateState
+	for i := 0; i < GridSize; i++ {
+		for j := 0; j < GridSize; j++ {
+			s.Winners[i][j], s.Closed[i][j] = g.smallBoard(Coord{Row: i, Col: j})
+		}
+	}
+
+	s.NextBoard = NoCoord
+	if len(g.Moves) > 0 {
+		last := g.Moves[len(g.Moves)-1]
+		next := Coord{Row: last.Row % GridSize, Col: last.Col % GridSize}
+		if !s.Closed[next.Row][next.Col] {
+			s.NextBoard = next
+		}
+	}
+	return s
+}
+
+// BoardOf returns the small board of a cell of the ultimate board
+func BoardOf(c Coord) Coord {
+	return Coord{Row: c.Row / GridSize, Col: c.Col / GridSize}
+}
+
+// smallBoard returns the winner of the small board and whether it is closed: won or full
+func (g *Game) smallBoard(b Coord) (Mark, bool) {
+	full := true
+	for i := 0; i < GridSize; i++ {
+		for j := 0; j < GridSize; j++ {
+			c := Coord{Row: b.Row*GridSize + i, Col: b.Col*GridSize + j}
+			mark := g.Grid[c.Row][c.Col]
+			if mark == Empty {
+				full = false
+			} else if g.smallLine(c) {
+				return mark, true
+			}
+		}
+	}
+	return Empty, full
+}
+
+// smallLine reports whether the mark in the given cell is part of a line within its small board
+func (g *Game) smallLine(c Coord) bool {
+	b := BoardOf(c)
+	mark := g.Grid[c.Row][c.Col]
+	for _, d := range lineDirections {
+		length := 1
+		for _, sign := range [2]int{1, -1} {
+			next := Coord{Row: c.Row + sign*d.Row, Col: c.Col + sign*d.Col}
+			for g.inside(next) && BoardOf(next) == b && g.Grid[next.Row][next.Col] == mark {
+				length++
+				next = Coord{Row: next.Row + sign*d.Row, Col: next.Col + sign*d.Col}
+			}
+		}
+		if length >= GridSize {
+			return true
+		}
+	}
+	return false
+}
+
+// ultimateMoves returns the empty cells of the small board the next move is sent to,
+// or of all open small boards if that one is closed or there was no move yet
+func (g *Game) ultimateMoves() []Coord {
+	s := g.Ultimate()
+	if over, _ := s.over(); over {
+		return nil
+	}
+
+	var cells []Coord
+	for _, c := range g.EmptyCells() {
+		b := BoardOf(c)
+		if s.Closed[b.Row][b.Col] || (s.NextBoard != NoCoord && b != s.NextBoard) {
+			continue
+		}
+		cells = append(cells, c)
+	}
+	return cells
+}
+
+// ultimateWins reports whether the move just made in the given cell wins its small board
+// and the small board completes a row of won small boards
+func (g *Game) ultimateWins(c Coord) bool {
+	return g.smallLine(c) && g.Ultimate().rowThrough(BoardOf(c))
+}
+
+// ultimatePlayout plays random legal moves starting with the current player until the ultimate game ends
+// and returns the winner, or Empty for a draw. The state of the small boards is updated move by move
+func (g *Game) ultimatePlayout(currentPlayer Mark, rnd *rand.Rand) Mark {
+	s := g.Ultimate()
+	if over, winner := s.over(); over {
+		return winner
+	}
+
+	cells := make([]Coord, 0, UltimateSize*UltimateSize)
+	for {
+		cells = cells[:0]
+		for i := range g.Grid {
+			for j := range g.Grid[i] {
+				c := Coord{Row: i, Col: j}
+				b := BoardOf(c)
+				if g.Grid[i][j] == Empty && !s.Closed[b.Row][b.Col] && (s.NextBoard == NoCoord || b == s.NextBoard) {
+					cells = append(cells, c)
+				}
+			}
+		}
+		if len(cells) == 0 {
+			return Empty
+		}
+
+		c := cells[rnd.Intn(len(cells))]
+		b := BoardOf(c)
+		g.Grid[c.Row][c.Col] = currentPlayer
+		if g.smallLine(c) {
+			s.Winners[b.Row][b.Col] = currentPlayer
+			s.Closed[b.Row][b.Col] = true
+			if s.rowThrough(b) {
+				return currentPlayer
+			}
+		} else {
+			_, s.Closed[b.Row][b.Col] = g.smallBoard(b)
+		}
+
+		s.NextBoard = Coord{Row: c.Row % GridSize, Col: c.Col % GridSize}
+		if s.Closed[s.NextBoard.Row][s.NextBoard.Col] {
+			s.NextBoard = NoCoord
+		}
+		currentPlayer = GetOpponent(currentPlayer)
+	}
+}
+
+// rowThrough reports whether the winner of the small board completes a row of won small boards through it
+func (s UltimateState) rowThrough(b Coord) bool {
+	if s.Winners[b.Row][b.Col] == Empty {
+		return false
+	}
+
+	macro := Game{Grid: NewGrid(GridSize), Rules: DefaultRules()}
+	for i := 0; i < GridSize; i++ {
+		for j := 0; j < GridSize; j++ {
+			macro.Grid[i][j] = s.Winners[i][j]
+		}
+	}
+	return macro.completesLine(b)
+}
+
+// ultimateOver checks if an ultimate game is finished: a row of small boards is won by one player
+// or all small boards are closed
+func (g *Game) ultimateOver() (bool, Mark) {
+	return g.Ultimate().over()
+}
+
+// over checks if there is a row of small boards won by one player or all small boards are closed.
+// Returns the finish status and the winner if there is one
+func (s UltimateState) over() (bool, Mark) {
+	macro := Game{Grid: NewGrid(GridSize), Rules: DefaultRules()}
+	open := false
+	for i := 0; i < GridSize; i++ {
+		for j := 0; j < GridSize; j++ {
+			macro.Grid[i][j] = s.Winners[i][j]
+			open = open || !s.Closed[i][j]
+		}
+	}
+
+	for i := range macro.Grid {
+		for j := range macro.Grid[i] {
+			if macro.Grid[i][j] != Empty && macro.lineFrom(Coord{Row: i, Col: j}) {
+				return true, macro.Grid[i][j]
+			}
+		}
+	}
+	return !open, Empty
+}
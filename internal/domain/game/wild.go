@@ -0,0 +1,158 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// wildPlacement is a move of a wild game: the cell and the mark chosen for it
+type wildPlacement struct {
+	cell Coord
+	mark Mark
+}
+
+// noPlacement represents an undefined move of a wild game
+var noPlacement = wildPlacement{cell: NoCoord, mark: Empty}
+
+// wildSearcher keeps the state of one depth-limited negamax search of a wild game
+type wildSearcher struct {
+	ctx     context.Context
+	g       *Game
+	nodes   int
+	aborted bool
+	cutoff  bool
+}
+
+// PlayerToMove returns the player who makes the next move, counted by the moves made:
+// Cross is the first player. In wild and notakto games it tells the players apart, as both place the same marks
+func (g *Game) PlayerToMove() Mark {
+	if g.MoveCount()%2 == 0 {
+		return Cross
+	}
+	return Nought
+}
+
+// MoveCount returns the number of moves made in the game of any variant
+func (g *Game) MoveCount() int {
+	return len(g.Moves) + len(g.BoardMoves) + len(g.Steps)
+}
+
+// SetWildMove checks the move of the player to move in a wild game, who may place either mark,
+// and sets the mark to the board. Returns an error if the move or the mark is not possible
+func (g *Game) SetWildMove(move Coord, mark Mark) error {
+	if !g.Rules.Wild {
+		return fmt.Errorf("no move possible: marks are only chosen in wild games")
+	}
+	if mark != Cross && mark != Nought {
+		return fmt.Errorf("no move possible: no such mark")
+	}
+	return g.SetPlayerMove(move, mark)
+}
+
+// lastMover returns the player who made the last move
+func (g *Game) lastMover() Mark {
+	return GetOpponent(g.PlayerToMove())
+}
+
+// WildMove returns the cell and the mark of the best move of the player to move in a wild game,
+// found by negamax search with alpha-beta pruning over both marks in every empty cell. The depth limit
+// grows by one ply after each completed iteration until the whole game tree is searched or ctx is done.
+// Also returns the depth of the deepest completed iteration. If ctx is done before the first iteration
+// completes, returns the first empty cell with the player's own mark and depth 0.
+// Returns an error if the game is over.
+func WildMove(ctx context.Context, g *Game) (Coord, Mark, int, error) {
+	if gameOver, _ := g.IsOver(); gameOver {
+		return NoCoord, Empty, 0, fmt.Errorf("could not find a valid move")
+	}
+
+	s := wildSearcher{ctx: ctx, g: g.Clone()}
+	best := noPlacement
+	bestDepth := 0
+	cells := len(g.EmptyCells())
+
+	for limit := 1; limit <= cells; limit++ {
+		s.cutoff = false
+		_, move := s.negamax(0, limit, -searchWinScore-1, searchWinScore+1, best)
+		if s.aborted {
+			break
+		}
+
+		best, bestDepth = move, limit
+		if !s.cutoff {
+			break
+		}
+	}
+
+	if best == noPlacement {
+		return g.EmptyCells()[0], g.PlayerToMove(), 0, nil
+	}
+	return best.cell, best.mark, bestDepth, nil
+}
+
+// negamax searches the position to the depth limit and returns its score for the player to move and the best move.
+// Placing a mark that completes a line wins, or loses under the misere rule. The first move is searched first:
+// it is the best move of the previous iteration.
+func (s *wildSearcher) negamax(depth, limit, alpha, beta int, first wildPlacement) (int, wildPlacement) {
+	s.nodes++
+	if s.nodes%contextCheckInterval == 0 && s.ctx.Err() != nil {
+		s.aborted = true
+	}
+	if s.aborted {
+		return 0, noPlacement
+	}
+
+	cells := s.g.EmptyCells()
+	if len(cells) == 0 {
+		return 0, noPlacement
+	}
+	if depth == limit {
+		s.cutoff = true
+		return 0, noPlacement
+	}
+
+	moves := make([]wildPlacement, 0, 2*len(cells)+1)
+	if first != noPlacement {
+		moves = append(moves, first)
+	}
+	for _, c := range cells {
+		for _, mark := range [2]Mark{Cross, Nought} {
+			if p := (wildPlacement{cell: c, mark: mark}); p != first {
+				moves = append(moves, p)
+			}
+		}
+	}
+
+	winScore := searchWinScore - (depth + 1)
+	bestScore := math.MinInt
+	best := noPlacement
+
+	for _, p := range moves {
+		s.g.Grid[p.cell.Row][p.cell.Col] = p.mark
+		var score int
+		if s.g.completesLine(p.cell) {
+			score = winScore
+			if s.g.Rules.Misere {
+				score = -winScore
+			}
+		} else {
+			score, _ = s.negamax(depth+1, limit, -beta, -alpha, noPlacement)
+			score = -score
+		}
+		s.g.Grid[p.cell.Row][p.cell.Col] = Empty
+
+		if s.aborted {
+			return 0, noPlacement
+		}
+
+		if score > bestScore {
+			bestScore, best = score, p
+		}
+		alpha = max(alpha, score)
+		if alpha >= beta || score == winScore {
+			break
+		}
+	}
+
+	return bestScore, best
+}
@@ -1,12 +1,23 @@
 package service
 
 import (
+	"context"
+	"tictactoe/internal/domain/engine"
 	"tictactoe/internal/domain/game"
+	"time"
 )
 
 // GameService defines the interface for operations with game logic
 type GameService interface {
-	GetNextMove(game *game.Game, currentPlayer game.Mark) error
+	CreateGame(rules game.Rules, engineName string) (*game.Game, error)
+	CreateGameFromLayout(rules game.Rules, layout game.Grid, engineName string) (*game.Game, error)
+	GetEngines() []engine.Strategy
+	GetLearningCurve(engineName string) ([]engine.LearningPoint, error)
+	PlayArena(ctx context.Context, first, second string, cfg engine.ArenaConfig) (engine.ArenaResult, error)
+	GetNextMove(ctx context.Context, game *game.Game, currentPlayer game.Mark) (engine.Result, error)
+	ExplainMove(game *game.Game, move game.Coord, currentPlayer game.Mark) game.Explanation
+	GetDailyPuzzle(date time.Time) (game.Puzzle, error)
+	CheckPuzzle(date time.Time, moves []game.Coord) (game.PuzzleCheck, error)
 	ValidateField(old, updated *game.Game) error
 	IsOver(game *game.Game) bool
 	SaveGame(g *game.Game)
@@ -1,68 +1,269 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"sync"
+	"tictactoe/internal/config"
 	"tictactoe/internal/datasource"
+	"tictactoe/internal/domain/engine"
 	"tictactoe/internal/domain/game"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type gameService struct {
-	repo datasource.GameRepository
+	repo     datasource.GameRepository
+	engines  *engine.Registry
+	moveTime time.Duration
+	puzzles  sync.Map // Daily puzzles of the served days generated so far by date
 }
 
-// NewGameService creates a new instance of GameService with GameRepository
-func NewGameService(r datasource.GameRepository) GameService {
-	return &gameService{
-		repo: r,
+// NewGameService creates a new instance of GameService with GameRepository, engine Registry
+// and the computer move time budget from Config. Restores the saved states of learning engines.
+// Returns an error if a saved state can't be read or restored: starting a blank engine would
+// overwrite the learned state on the next save
+func NewGameService(r datasource.GameRepository, engines *engine.Registry, cfg *config.Config) (GameService, error) {
+	s := &gameService{
+		repo:     r,
+		engines:  engines,
+		moveTime: cfg.MoveTime,
 	}
+
+	for _, learner := range s.learners() {
+		data, err := r.LoadEngineState(learner.Name())
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := learner.UnmarshalState(data); err != nil {
+			return nil, fmt.Errorf("failed to restore engine %s: %w", learner.Name(), err)
+		}
+	}
+
+	return s, nil
 }
 
-// GetNextMove calculates and performs the next move for the given player using the minimax algorithm.
-// Returns an error if the move cannot be determined or applied
-func (s *gameService) GetNextMove(g *game.Game, currentPlayer game.Mark) error {
-	coord, err := g.NextMove(currentPlayer)
+// CreateGame creates and saves a new game with the given rules and computer engine.
+// An empty engine name selects the default engine for the rules.
+// Returns an error if the rules are not supported or the engine can't play them
+func (s *gameService) CreateGame(rules game.Rules, engineName string) (*game.Game, error) {
+	g, err := game.NewGameWithRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	return s.startGame(g, engineName)
+}
 
+// CreateGameFromLayout creates and saves a new game with the given rules that starts from the layout,
+// which may contain blocked cells. An empty engine name selects the default engine for the rules.
+// Returns an error if the rules or the layout are not supported or the engine can't play them
+func (s *gameService) CreateGameFromLayout(rules game.Rules, layout game.Grid, engineName string) (*game.Game, error) {
+	g, err := game.NewGameFromLayout(rules, layout)
 	if err != nil {
-		return fmt.Errorf("%v", err)
+		return nil, err
 	}
+	return s.startGame(g, engineName)
+}
 
-	g.Grid[coord.Row][coord.Col] = currentPlayer
-	return nil
+// startGame sets the engine that can play the rules of the new game and saves it
+func (s *gameService) startGame(g *game.Game, engineName string) (*game.Game, error) {
+	strategy, err := s.engines.Resolve(engineName, g.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Engine = strategy.Name()
+	s.SaveGame(g)
+	return g, nil
+}
+
+// GetEngines returns all computer engines that can be chosen for a game
+func (s *gameService) GetEngines() []engine.Strategy {
+	return s.engines.List()
+}
+
+// GetLearningCurve returns the learning progress of the named engine.
+// Returns an error if the engine doesn't exist or doesn't learn
+func (s *gameService) GetLearningCurve(engineName string) ([]engine.LearningPoint, error) {
+	strategy, err := s.engines.Get(engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	learner, ok := strategy.(engine.Learner)
+	if !ok {
+		return nil, fmt.Errorf("engine %q does not learn", engineName)
+	}
+	return learner.Curve(), nil
+}
+
+// PlayArena plays a match between two named engines. Empty names select the default engine for the rules,
+// zero move time selects the configured one. Returns an error if an engine can't play the match
+func (s *gameService) PlayArena(ctx context.Context, first, second string, cfg engine.ArenaConfig) (engine.ArenaResult, error) {
+	firstStrategy, err := s.engines.Resolve(first, cfg.Rules)
+	if err != nil {
+		return engine.ArenaResult{}, err
+	}
+
+	secondStrategy, err := s.engines.Resolve(second, cfg.Rules)
+	if err != nil {
+		return engine.ArenaResult{}, err
+	}
+
+	if cfg.MoveTime == 0 {
+		cfg.MoveTime = s.moveTime
+	}
+	return engine.PlayArena(ctx, firstStrategy, secondStrategy, cfg)
+}
+
+// GetNextMove calculates and performs the next move for the given player using the engine chosen for the game.
+// The search is limited by the move time budget and stops early when ctx is cancelled.
+// Returns the engine result or an error if the move cannot be determined or applied
+func (s *gameService) GetNextMove(ctx context.Context, g *game.Game, currentPlayer game.Mark) (engine.Result, error) {
+	strategy, err := s.engines.Resolve(g.Engine, g.Rules)
+	if err != nil {
+		return engine.Result{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.moveTime)
+	defer cancel()
+
+	res, err := strategy.NextMove(ctx, g, currentPlayer)
+
+	if err != nil {
+		return engine.Result{}, fmt.Errorf("%v", err)
+	}
+
+	res.Apply(g, currentPlayer)
+	return res, nil
+}
+
+// ExplainMove explains the move of the current player in the position before the move
+func (s *gameService) ExplainMove(g *game.Game, move game.Coord, currentPlayer game.Mark) game.Explanation {
+	return game.ExplainMove(g, move, currentPlayer)
+}
+
+// GetDailyPuzzle returns the puzzle of the day of the date, generated on the first request for the day.
+// Only the puzzles of the days served today are kept, the ones of the days that are no longer served
+// are dropped, so at most 2*game.PuzzleDays+1 puzzles are cached. The day is expected to be served today.
+// Returns an error if no puzzle was found
+func (s *gameService) GetDailyPuzzle(date time.Time) (game.Puzzle, error) {
+	now := time.Now()
+	key := date.UTC().Format(time.DateOnly)
+	if p, ok := s.puzzles.Load(key); ok {
+		return p.(game.Puzzle), nil
+	}
+
+	p, err := game.DailyPuzzle(date)
+	if err != nil {
+		return game.Puzzle{}, err
+	}
+
+	s.puzzles.Store(key, p)
+	s.puzzles.Range(func(key, _ any) bool {
+		if day, err := time.Parse(time.DateOnly, key.(string)); err != nil || !game.PuzzleServed(day, now) {
+			s.puzzles.Delete(key)
+		}
+		return true
+	})
+	return p, nil
+}
+
+// CheckPuzzle plays the submitted moves against the defence of the puzzle of the day of the date.
+// Returns an error if there is no puzzle for the day
+func (s *gameService) CheckPuzzle(date time.Time, moves []game.Coord) (game.PuzzleCheck, error) {
+	p, err := s.GetDailyPuzzle(date)
+	if err != nil {
+		return game.PuzzleCheck{}, err
+	}
+	return p.Check(moves), nil
 }
 
 // IsOver checks whether the game is over and sets the final state and winner.
+// A learning engine of a just completed game learns from it.
 // Saves the game to the repository and returns true if the game is over
 func (s *gameService) IsOver(g *game.Game) bool {
 	isOver, winner := g.IsOver()
 	if isOver {
+		justCompleted := g.State != game.Completed
 		g.State = game.Completed
 		g.Winner = winner
+		if justCompleted {
+			s.learn(g)
+		}
 	}
 	s.SaveGame(g)
 	return isOver
 }
 
+// learn passes the completed game to its engine if the engine learns.
+// The computer always plays Nought
+func (s *gameService) learn(g *game.Game) {
+	strategy, err := s.engines.Resolve(g.Engine, g.Rules)
+	if err != nil {
+		return
+	}
+
+	if learner, ok := strategy.(engine.Learner); ok {
+		learner.Learn(g, game.Nought)
+	}
+}
+
+// learners returns all registered engines that learn
+func (s *gameService) learners() []engine.Learner {
+	var learners []engine.Learner
+	for _, strategy := range s.engines.List() {
+		if learner, ok := strategy.(engine.Learner); ok {
+			learners = append(learners, learner)
+		}
+	}
+	return learners
+}
+
 // ValidateField compares two game states and ensures that exactly one cell is different,
-// indicating a valid move. Returns an error if the move is invalid
+// indicating a valid move, or two cells for a mark moved in a morris game.
+// The boards of notakto games are compared as well. Returns an error if the move is invalid
 func (s *gameService) ValidateField(old, updated *game.Game) error {
-	diffCount := 0
+	if len(old.Grid) != len(updated.Grid) || len(old.Boards) != len(updated.Boards) {
+		return fmt.Errorf("invalid move on field")
+	}
 
-	for i := 0; i < game.GridSize; i++ {
-		for j := 0; j < game.GridSize; j++ {
-			if old.Grid[i][j] != updated.Grid[i][j] {
-				diffCount++
-			}
-		}
+	diffCount := countDiffs(old.Grid, updated.Grid)
+	for b := range old.Boards {
+		diffCount += countDiffs(old.Boards[b], updated.Boards[b])
 	}
 
-	if diffCount != 1 {
+	expected := 1
+	if n := len(updated.Steps); n > len(old.Steps) && updated.Steps[n-1].From != game.NoCoord {
+		expected = 2
+	}
+
+	if diffCount != expected {
 		return fmt.Errorf("invalid move on field")
 	}
 	return nil
 }
 
+// countDiffs returns the number of cells that differ between two grids of the same size
+func countDiffs(old, updated game.Grid) int {
+	diffCount := 0
+
+	for i := range old {
+		for j := range old[i] {
+			if old[i][j] != updated[i][j] {
+				diffCount++
+			}
+		}
+	}
+	return diffCount
+}
+
 // SaveGame saves the given game to the repository
 func (s *gameService) SaveGame(g *game.Game) {
 	s.repo.SaveGame(g)
@@ -83,6 +284,20 @@ func (s *gameService) GetAllGames() ([]*game.Game, error) {
 }
 
 // SaveGames save all games from repository to json file
+// together with the learned states of engines
 func (s *gameService) SaveGames() error {
-	return s.repo.SaveGames()
+	if err := s.repo.SaveGames(); err != nil {
+		return err
+	}
+
+	for _, learner := range s.learners() {
+		data, err := learner.MarshalState()
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SaveEngineState(learner.Name(), data); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+	"tictactoe/internal/config"
+	"tictactoe/internal/datasource"
+	"tictactoe/internal/domain/engine"
+)
+
+// stateRepository is a GameRepository that only serves the saved engine states
+type stateRepository struct {
+	datasource.GameRepository
+	states map[string][]byte
+}
+
+// LoadEngineState returns the saved state of the engine, fs.ErrNotExist if there is none
+func (r stateRepository) LoadEngineState(name string) ([]byte, error) {
+	if data, ok := r.states[name]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("failed to read file: %w", fs.ErrNotExist)
+}
+
+func TestNewGameServiceEngineStates(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  map[string][]byte
+		wantErr bool
+	}{
+		{name: "no state", states: map[string][]byte{}},
+		{name: "saved state", states: map[string][]byte{engine.MenaceName: []byte(`{"boxes": {}, "curve": []}`)}},
+		{name: "corrupt state", states: map[string][]byte{engine.MenaceName: []byte(`{"boxes": `)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engines, err := engine.NewRegistry([]engine.Strategy{engine.NewMenaceStrategy()})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = NewGameService(stateRepository{states: tt.states}, engines, &config.Config{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGameService error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
 	"tictactoe/internal/domain/game"
 	"tictactoe/internal/domain/service"
@@ -21,12 +22,17 @@ func NewGameHandler(s service.GameService) *GameHandler {
 // ProcessMove handles a POST request to make a move in a game.
 // If no game ID is provided, it creates a new game.
 // It validates the player's move, performs the opponent's move,
-// checks for game over, and returns the updated game state.
+// checks for game over, and returns the updated game state with the computer search depth
+// and, if the request asks for it, the explanation of the computer move. Moves of multi-board and morris games are not explained.
+// Fog games return the view of the player instead, a move onto a hidden mark of the computer only reveals it
 func (h *GameHandler) ProcessMove(c *gin.Context) {
 	strID := c.Param("id")
 	if strID == "" {
-		g := game.NewGame()
-		h.gameService.SaveGame(g)
+		g, err := h.gameService.CreateGame(game.DefaultRules(), "")
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
 		strID = g.ID.String()
 	}
 
@@ -44,8 +50,43 @@ func (h *GameHandler) ProcessMove(c *gin.Context) {
 		return
 	}
 
-	newGame := *oldGame
-	errMove := newGame.SetPlayerMove(game.Coord{Row: move.Row, Col: move.Col}, game.Cross)
+	newGame := *oldGame.Clone()
+	coord := game.Coord{Row: move.Row, Col: move.Col}
+	if newGame.Rules.Gravity {
+		var errDrop error
+		if coord, errDrop = newGame.Drop(move.Col); errDrop != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": errDrop.Error()})
+			return
+		}
+	}
+
+	if newGame.Rules.Fog {
+		hit, errProbe := newGame.Probe(coord, game.Cross)
+		if errProbe != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": errProbe.Error()})
+			return
+		}
+		if hit {
+			h.gameService.SaveGame(&newGame)
+			res := ToViewResponse(&newGame, game.Cross)
+			res.Hit = true
+			c.IndentedJSON(http.StatusOK, res)
+			return
+		}
+	}
+
+	var errMove error
+	if newGame.Rules.Variant == game.Notakto {
+		errMove = newGame.SetBoardMove(game.BoardCoord{Board: move.Board, Row: move.Row, Col: move.Col}, game.Cross)
+	} else if newGame.Rules.Variant == game.Qubic {
+		errMove = newGame.SetBoardMove(game.BoardCoord{Board: move.Layer, Row: move.Row, Col: move.Col}, game.Cross)
+	} else if newGame.Rules.Variant == game.Morris {
+		errMove = newGame.SetStep(ToStep(move), game.Cross)
+	} else if newGame.Rules.Wild {
+		errMove = newGame.SetWildMove(coord, game.Mark(move.Mark))
+	} else {
+		errMove = newGame.SetPlayerMove(coord, game.Cross)
+	}
 	if errMove != nil {
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": errMove.Error()})
 		return
@@ -58,11 +99,12 @@ func (h *GameHandler) ProcessMove(c *gin.Context) {
 	}
 
 	if isOver := h.gameService.IsOver(&newGame); isOver == true {
-		c.IndentedJSON(http.StatusOK, ToGameResponse(&newGame))
+		c.IndentedJSON(http.StatusOK, ToClientResponse(&newGame))
 		return
 	}
 
-	errNextMove := h.gameService.GetNextMove(&newGame, game.Nought)
+	beforeMove := newGame.Clone()
+	result, errNextMove := h.gameService.GetNextMove(c.Request.Context(), &newGame, game.Nought)
 	if errNextMove != nil {
 		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": errNextMove.Error()})
 		return
@@ -70,11 +112,52 @@ func (h *GameHandler) ProcessMove(c *gin.Context) {
 
 	h.gameService.IsOver(&newGame)
 	h.gameService.SaveGame(&newGame)
-	c.IndentedJSON(http.StatusOK, ToGameResponse(&newGame))
+
+	if newGame.Rules.Fog {
+		c.IndentedJSON(http.StatusOK, ToViewResponse(&newGame, game.Cross))
+		return
+	}
+
+	res := ToGameResponse(&newGame)
+	res.Depth = result.Depth
+	if move.Explain && !newGame.Rules.Variant.MultiBoard() && newGame.Rules.Variant != game.Morris {
+		res.Explanation = ToExplanationResponse(h.gameService.ExplainMove(beforeMove, result.Coord, result.PlacedMark(game.Nought)))
+	}
+	c.IndentedJSON(http.StatusOK, res)
+}
+
+// CreateGame handles a POST request to create a new game with the given board options,
+// started from the layout of the request if it has one.
+// Returns the new game state or an error if the options or the layout are not supported
+func (h *GameHandler) CreateGame(c *gin.Context) {
+	var req NewGameRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rules, err := ToRules(req)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var g *game.Game
+	if layout := ToLayout(req); layout != nil {
+		g, err = h.gameService.CreateGameFromLayout(rules, layout, req.Engine)
+	} else {
+		g, err = h.gameService.CreateGame(rules, req.Engine)
+	}
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, ToClientResponse(g))
 }
 
 // GetAllGames handles a GET request to retrieve all saved games.
-// Returns a list of game states or an error if no games are found
+// Returns a list of game states, views of fog games, or an error if no games are found
 func (h *GameHandler) GetAllGames(c *gin.Context) {
 	games, err := h.gameService.GetAllGames()
 	if err != nil {
@@ -84,10 +167,9 @@ func (h *GameHandler) GetAllGames(c *gin.Context) {
 		return
 	}
 
-	var res []GameResponse
+	var res []any
 	for i := 0; i < len(games); i++ {
-		gr := ToGameResponse(games[i])
-		res = append(res, gr)
+		res = append(res, ToClientResponse(games[i]))
 	}
 
 	c.IndentedJSON(http.StatusOK, res)
@@ -106,7 +188,107 @@ func (h *GameHandler) GetGameByID(c *gin.Context) {
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, ToGameResponse(game))
+	c.IndentedJSON(http.StatusOK, ToClientResponse(game))
+}
+
+// GetEngines handles a GET request to list the computer engines that can be chosen for a game
+func (h *GameHandler) GetEngines(c *gin.Context) {
+	var res []EngineResponse
+	for _, s := range h.gameService.GetEngines() {
+		res = append(res, ToEngineResponse(s))
+	}
+
+	c.IndentedJSON(http.StatusOK, res)
+}
+
+// GetLearningCurve handles a GET request to retrieve the learning progress of a learning engine.
+// Returns total results after every learned game or an error if the engine doesn't learn
+func (h *GameHandler) GetLearningCurve(c *gin.Context) {
+	curve, err := h.gameService.GetLearningCurve(c.Param("name"))
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, curve)
+}
+
+// MaxArenaGames is the largest number of games in a match requested over HTTP
+const MaxArenaGames = 1000
+
+// PlayArena handles a POST request to play a match between two computer engines.
+// Returns the match results or an error if the settings are invalid
+func (h *GameHandler) PlayArena(c *gin.Context) {
+	var req ArenaRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Games < 1 || req.Games > MaxArenaGames {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("games must be between 1 and %d", MaxArenaGames)})
+		return
+	}
+
+	cfg, err := ToArenaConfig(req)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.gameService.PlayArena(c.Request.Context(), req.First, req.Second, cfg)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, ToArenaResponse(res))
+}
+
+// GetDailyPuzzle handles a GET request to retrieve the puzzle of the day given by the date query
+// parameter as YYYY-MM-DD, today if it is missing. Returns the puzzle or an error if the date is malformed,
+// more than game.PuzzleDays away from today or no puzzle was found for the day
+func (h *GameHandler) GetDailyPuzzle(c *gin.Context) {
+	date, err := ToPuzzleDate(c.Query("date"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, err := h.gameService.GetDailyPuzzle(date)
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, ToPuzzleResponse(date, p))
+}
+
+// CheckDailyPuzzle handles a POST request to check a solution line of the daily puzzle.
+// Returns the line played with the replies of the defence and whether it solves the puzzle,
+// or an error if the request is invalid or no puzzle was found for the day
+func (h *GameHandler) CheckDailyPuzzle(c *gin.Context) {
+	var req PuzzleSolutionRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date, err := ToPuzzleDate(req.Date)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	check, err := h.gameService.CheckPuzzle(date, ToPuzzleMoves(req))
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, ToPuzzleCheckResponse(check))
 }
 
 // SaveAllGames handles a POST request to persist all games currently stored in memory.
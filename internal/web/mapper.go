@@ -1,6 +1,11 @@
 package web
 
-import "tictactoe/internal/domain/game"
+import (
+	"fmt"
+	"tictactoe/internal/domain/engine"
+	"tictactoe/internal/domain/game"
+	"time"
+)
 
 // ToMoveRequest creates a MoveRequest from a game state and coordinate
 func ToMoveRequest(g *game.Game, coord game.Coord) MoveRequest {
@@ -10,18 +15,304 @@ func ToMoveRequest(g *game.Game, coord game.Coord) MoveRequest {
 	}
 }
 
+// ToStep converts a MoveRequest of a morris game into a game.Step
+func ToStep(r MoveRequest) game.Step {
+	step := game.Step{From: game.NoCoord, To: game.Coord{Row: r.Row, Col: r.Col}}
+	if r.From != nil {
+		step.From = game.Coord{Row: r.From.Row, Col: r.From.Col}
+	}
+	return step
+}
+
+// ToRules converts a NewGameRequest into game.Rules, filling zero values with the default ones of the variant.
+// The size of the layout, if there is one, is the default size. Returns an error if the variant is unknown
+func ToRules(r NewGameRequest) (game.Rules, error) {
+	variant, err := game.ParseVariant(r.Variant)
+	if err != nil {
+		return game.Rules{}, err
+	}
+
+	rules := variant.Rules()
+	defaultWinLength := game.GridSize
+	if r.Gravity {
+		rules.Size, rules.WinLength = game.GravitySize, game.GravityWinLength
+		defaultWinLength = game.GravityWinLength
+	}
+	size := r.Size
+	if size == 0 {
+		size = len(r.Layout)
+	}
+	if size != 0 {
+		rules.Size = size
+		rules.WinLength = min(size, defaultWinLength)
+	}
+	if r.WinLength != 0 {
+		rules.WinLength = r.WinLength
+	}
+	rules.ExactFive = r.ExactFive
+	rules.Misere = r.Misere
+	rules.Gravity = r.Gravity
+	rules.Wild = r.Wild
+	rules.Torus = r.Torus
+	rules.Fog = r.Fog
+	if r.Boards != 0 {
+		rules.Boards = r.Boards
+	}
+	return rules, nil
+}
+
+// ToLayout converts the layout of a NewGameRequest into a game.Grid, nil if there is none
+func ToLayout(r NewGameRequest) game.Grid {
+	if r.Layout == nil {
+		return nil
+	}
+
+	layout := make(game.Grid, len(r.Layout))
+	for i := range r.Layout {
+		layout[i] = make([]game.Mark, len(r.Layout[i]))
+		for j := range r.Layout[i] {
+			layout[i][j] = game.Mark(r.Layout[i][j])
+		}
+	}
+	return layout
+}
+
 // ToGameResponse converts a game.Game instance into a GameResponse.
 func ToGameResponse(g *game.Game) GameResponse {
 	gr := GameResponse{}
 	gr.ID = g.ID.String()
 	gr.State = int(g.State)
 	gr.Winner = int(g.Winner)
+	gr.WinLength = g.Rules.WinLength
+	gr.Engine = g.Engine
+	gr.Variant = g.Rules.Variant.String()
+	gr.ExactFive = g.Rules.ExactFive
+	gr.Misere = g.Rules.Misere
+	gr.Gravity = g.Rules.Gravity
+	gr.Wild = g.Rules.Wild
+	gr.Torus = g.Rules.Torus
+	gr.Custom = g.Rules.Custom
+
+	gr.Grid = toGridResponse(g.Grid)
 
-	for i := 0; i < game.GridSize; i++ {
-		for j := 0; j < game.GridSize; j++ {
-			gr.Grid[i][j] = int(g.Grid[i][j])
+	if g.Rules.Variant == game.Ultimate {
+		gr.Ultimate = toUltimateResponse(g.Ultimate())
+	}
+	if g.Rules.Variant == game.Notakto {
+		gr.Notakto = toNotaktoResponse(g)
+	}
+	if g.Rules.Variant == game.Qubic {
+		gr.Qubic = &QubicResponse{Layers: toBoardsResponse(g.Boards)}
+	}
+	if g.Rules.Variant == game.Morris {
+		gr.Morris = &MorrisResponse{
+			Placing:     g.Placing(),
+			Repetitions: g.Repetitions(),
 		}
 	}
 
 	return gr
 }
+
+// ToViewResponse converts a fog game into the ViewResponse of the player
+func ToViewResponse(g *game.Game, player game.Mark) ViewResponse {
+	vr := ViewResponse{
+		ID:        g.ID.String(),
+		State:     int(g.State),
+		View:      toGridResponse(g.View(player)),
+		Winner:    int(g.Winner),
+		WinLength: g.Rules.WinLength,
+		Engine:    g.Engine,
+		Torus:     g.Rules.Torus,
+	}
+	if gameOver, _ := g.IsOver(); !gameOver {
+		vr.Hidden = g.HiddenMarks(player)
+	}
+	return vr
+}
+
+// ToClientResponse converts a game into what the player may see: the ViewResponse of Cross
+// for fog games, the GameResponse for other games
+func ToClientResponse(g *game.Game) any {
+	if g.Rules.Fog {
+		return ToViewResponse(g, game.Cross)
+	}
+	return ToGameResponse(g)
+}
+
+// toUltimateResponse converts the state of the small boards of an ultimate game into an UltimateResponse
+func toUltimateResponse(s game.UltimateState) *UltimateResponse {
+	res := UltimateResponse{}
+	for i := range s.Winners {
+		res.Boards = append(res.Boards, make([]int, len(s.Winners[i])))
+		res.Closed = append(res.Closed, s.Closed[i][:])
+		for j := range s.Winners[i] {
+			res.Boards[i][j] = int(s.Winners[i][j])
+		}
+	}
+
+	if s.NextBoard != game.NoCoord {
+		next := toCoordResponse(s.NextBoard)
+		res.NextBoard = &next
+	}
+	return &res
+}
+
+// toNotaktoResponse converts the boards of a notakto game into a NotaktoResponse
+func toNotaktoResponse(g *game.Game) *NotaktoResponse {
+	res := NotaktoResponse{Boards: toBoardsResponse(g.Boards)}
+	for b := range g.Boards {
+		res.Dead = append(res.Dead, g.BoardDead(b))
+	}
+	return &res
+}
+
+// toBoardsResponse converts the boards of a multi-board game into rows of mark numbers
+func toBoardsResponse(boards []game.Grid) [][][]int {
+	var res [][][]int
+	for _, board := range boards {
+		res = append(res, toGridResponse(board))
+	}
+	return res
+}
+
+// toGridResponse converts a game.Grid into rows of mark numbers
+func toGridResponse(grid game.Grid) [][]int {
+	cells := make([][]int, len(grid))
+	for i := range grid {
+		cells[i] = make([]int, len(grid[i]))
+		for j := range grid[i] {
+			cells[i][j] = int(grid[i][j])
+		}
+	}
+	return cells
+}
+
+// ToEngineResponse converts an engine.Strategy into an EngineResponse
+func ToEngineResponse(s engine.Strategy) EngineResponse {
+	return EngineResponse{
+		Name:        s.Name(),
+		Description: s.Description(),
+		Standard:    s.Supports(game.DefaultRules()),
+		Larger:      s.Supports(game.Rules{Size: game.MaxGridSize, WinLength: game.GridSize}),
+		Variants:    toVariantNames(s),
+	}
+}
+
+// toVariantNames returns the names of the variants the strategy can play with their default rules
+func toVariantNames(s engine.Strategy) []string {
+	var names []string
+	for _, v := range game.Variants() {
+		if s.Supports(v.Rules()) {
+			names = append(names, v.String())
+		}
+	}
+	return names
+}
+
+// ToArenaConfig converts an ArenaRequest into engine.ArenaConfig.
+// Returns an error if the variant is unknown
+func ToArenaConfig(r ArenaRequest) (engine.ArenaConfig, error) {
+	rules, err := ToRules(NewGameRequest{Size: r.Size, WinLength: r.WinLength, Variant: r.Variant, Boards: r.Boards})
+	if err != nil {
+		return engine.ArenaConfig{}, err
+	}
+
+	return engine.ArenaConfig{
+		Games:    r.Games,
+		Rules:    rules,
+		MoveTime: time.Duration(r.MoveTime) * time.Millisecond,
+	}, nil
+}
+
+// ToArenaResponse converts an engine.ArenaResult into an ArenaResponse
+func ToArenaResponse(res engine.ArenaResult) ArenaResponse {
+	return ArenaResponse{
+		First:          res.First,
+		Second:         res.Second,
+		Games:          res.Games,
+		Wins:           res.Wins,
+		Draws:          res.Draws,
+		Losses:         res.Losses,
+		AverageLength:  res.AverageLength,
+		FirstMoveTime:  milliseconds(res.FirstMoveTime),
+		SecondMoveTime: milliseconds(res.SecondMoveTime),
+		Duration:       milliseconds(res.Duration),
+	}
+}
+
+// ToExplanationResponse converts a game.Explanation into an ExplanationResponse
+func ToExplanationResponse(e game.Explanation) *ExplanationResponse {
+	return &ExplanationResponse{
+		Move:      toCoordResponse(e.Move),
+		Reason:    e.Reason.String(),
+		Cells:     toCoordResponses(e.Cells),
+		Score:     e.Score,
+		Solved:    e.Solved,
+		Variation: toCoordResponses(e.Variation),
+	}
+}
+
+// ToPuzzleDate parses the day of a daily puzzle as YYYY-MM-DD, an empty string is today.
+// Returns an error if the day is malformed or its puzzle is not served today
+func ToPuzzleDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Now(), nil
+	}
+
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date must be YYYY-MM-DD")
+	}
+	if !game.PuzzleServed(date, time.Now()) {
+		return time.Time{}, fmt.Errorf("date must be within %d days of today", game.PuzzleDays)
+	}
+	return date, nil
+}
+
+// ToPuzzleResponse converts the game.Puzzle of the date into a PuzzleResponse
+func ToPuzzleResponse(date time.Time, p game.Puzzle) PuzzleResponse {
+	return PuzzleResponse{
+		Date:      date.UTC().Format(time.DateOnly),
+		Grid:      toGridResponse(p.Layout),
+		WinLength: p.Rules.WinLength,
+		Moves:     p.Moves,
+	}
+}
+
+// ToPuzzleMoves converts the moves of a PuzzleSolutionRequest into game coordinates
+func ToPuzzleMoves(r PuzzleSolutionRequest) []game.Coord {
+	var moves []game.Coord
+	for _, m := range r.Moves {
+		moves = append(moves, game.Coord{Row: m.Row, Col: m.Col})
+	}
+	return moves
+}
+
+// ToPuzzleCheckResponse converts a game.PuzzleCheck into a PuzzleCheckResponse
+func ToPuzzleCheckResponse(check game.PuzzleCheck) PuzzleCheckResponse {
+	return PuzzleCheckResponse{
+		Solved: check.Solved,
+		Line:   toCoordResponses(check.Line),
+		Reason: check.Reason,
+	}
+}
+
+// toCoordResponse converts a game.Coord into a CoordResponse
+func toCoordResponse(c game.Coord) CoordResponse {
+	return CoordResponse{Row: c.Row, Col: c.Col}
+}
+
+// toCoordResponses converts game coordinates into CoordResponses
+func toCoordResponses(coords []game.Coord) []CoordResponse {
+	var res []CoordResponse
+	for _, c := range coords {
+		res = append(res, toCoordResponse(c))
+	}
+	return res
+}
+
+// milliseconds converts a duration into fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -1,17 +1,168 @@
 package web
 
-import "tictactoe/internal/domain/game"
-
-// MoveRequest represents a player's move on the game grid
+// MoveRequest represents a player's move on the game grid.
+// Gravity games only take the column: the mark falls to the lowest empty cell.
+// Wild games also take the mark the player places, notakto games the board of the cell,
+// qubic games its layer and morris games the cell of the moved mark once all marks are placed
 type MoveRequest struct {
-	Row int `json:"row"` // Row index (0-based)
-	Col int `json:"col"` // Column index (0-based)
+	Row     int            `json:"row"`     // Row index (0-based), ignored by gravity games
+	Col     int            `json:"col"`     // Column index (0-based)
+	Mark    int            `json:"mark"`    // Mark to place in wild games: 1 for X, 2 for O
+	Board   int            `json:"board"`   // Board index (0-based) in notakto games
+	Layer   int            `json:"layer"`   // Layer index (0-based) in qubic games
+	From    *CoordResponse `json:"from"`    // Cell of the mark moved in morris games, null for placements
+	Explain bool           `json:"explain"` // Explain the computer reply in the response
+}
+
+// NewGameRequest represents the options of a new game.
+// Zero values select the standard 3x3 board and the default engine for the board
+type NewGameRequest struct {
+	Size      int     `json:"size"`      // Number of rows and cols
+	WinLength int     `json:"winLength"` // Number of same marks in a row needed to win
+	Engine    string  `json:"engine"`    // Name of the computer opponent engine
+	Variant   string  `json:"variant"`   // Rule set: classic, ultimate, gomoku, notakto, morris or qubic, classic if empty
+	ExactFive bool    `json:"exactFive"` // Gomoku only: a line longer than five doesn't win
+	Misere    bool    `json:"misere"`    // Classic only: completing a line loses instead of wins
+	Gravity   bool    `json:"gravity"`   // Classic only: moves choose a column, 7x7 with four in a row by default
+	Wild      bool    `json:"wild"`      // Classic only: both players place either mark
+	Torus     bool    `json:"torus"`     // Classic only: lines wrap around the edges of the board
+	Boards    int     `json:"boards"`    // Notakto only: number of boards, 3 by default
+	Fog       bool    `json:"fog"`       // Classic only: each player sees only their own marks and the ones they hit
+	Layout    [][]int `json:"layout"`    // Classic only: starting grid, 0 empty, 1 X, 2 O, 3 blocked; sets the size
 }
 
 // GameResponse is the JSON-serializable representation of a game state
 type GameResponse struct {
-	ID     string                            `json:"gameID"`
-	State  int                               `json:"state"`
-	Grid   [game.GridSize][game.GridSize]int `json:"grid"`
-	Winner int                               `json:"winner"`
+	ID        string  `json:"gameID"`
+	State     int     `json:"state"`
+	Grid      [][]int `json:"grid"`
+	Winner    int     `json:"winner"`
+	WinLength int     `json:"winLength"`
+	Engine    string  `json:"engine"`
+	Variant   string  `json:"variant"`
+	ExactFive bool    `json:"exactFive,omitempty"`
+	Misere    bool    `json:"misere,omitempty"`
+	Gravity   bool    `json:"gravity,omitempty"`
+	Wild      bool    `json:"wild,omitempty"`   // Winner is the player: 1 for the first (you), 2 for the computer
+	Torus     bool    `json:"torus,omitempty"`  // Lines wrap around the edges of the board
+	Custom    bool    `json:"custom,omitempty"` // Game started from a layout, 3 marks blocked cells in grid
+	Depth     int     `json:"depth,omitempty"`  // Search depth of the computer move, if one was made
+
+	Ultimate *UltimateResponse `json:"ultimate,omitempty"` // State of the small boards of an ultimate game
+	Notakto  *NotaktoResponse  `json:"notakto,omitempty"`  // Boards of a notakto game
+	Morris   *MorrisResponse   `json:"morris,omitempty"`   // Phase and repetitions of a morris game
+	Qubic    *QubicResponse    `json:"qubic,omitempty"`    // Layers of a qubic game
+
+	Explanation *ExplanationResponse `json:"explanation,omitempty"` // Why the computer made its move, if asked
+}
+
+// ViewResponse is the JSON-serializable view of a fog game for the player, returned instead of GameResponse.
+// The view shows the marks of the player and the marks of the computer they hit, all marks once the game is over
+type ViewResponse struct {
+	ID        string  `json:"gameID"`
+	State     int     `json:"state"`
+	View      [][]int `json:"view"`
+	Winner    int     `json:"winner"`
+	WinLength int     `json:"winLength"`
+	Engine    string  `json:"engine"`
+	Torus     bool    `json:"torus,omitempty"` // Lines wrap around the edges of the board
+	Hit       bool    `json:"hit"`             // Move hit a hidden mark of the computer and was not made, move again
+	Hidden    int     `json:"hidden"`          // Number of the marks of the computer the view doesn't show
+}
+
+// UltimateResponse is the JSON-serializable state of the small boards of an ultimate game
+type UltimateResponse struct {
+	Boards    [][]int        `json:"boards"`    // Winners of the small boards, 0 for undecided and drawn ones
+	Closed    [][]bool       `json:"closed"`    // Small boards that can't be played any more
+	NextBoard *CoordResponse `json:"nextBoard"` // Small board of the next move, null for any open one
+}
+
+// NotaktoResponse is the JSON-serializable state of the boards of a notakto game
+type NotaktoResponse struct {
+	Boards [][][]int `json:"boards"` // Crosses of every board, 1 for a cross and 0 for an empty cell
+	Dead   []bool    `json:"dead"`   // Boards with a line that can't be played any more
+}
+
+// MorrisResponse is the JSON-serializable state of a morris game
+type MorrisResponse struct {
+	Placing     bool `json:"placing"`     // Player to move still places marks instead of moving them
+	Repetitions int  `json:"repetitions"` // Occurrences of the position, the third one draws the game
+}
+
+// QubicResponse is the JSON-serializable cube of a qubic game
+type QubicResponse struct {
+	Layers [][][]int `json:"layers"` // Marks of every layer from top to bottom, rows and cols as in grid
+}
+
+// CoordResponse is the JSON-serializable cell of the game grid
+type CoordResponse struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// ExplanationResponse is the JSON-serializable explanation of a computer move
+type ExplanationResponse struct {
+	Move      CoordResponse   `json:"move"`
+	Reason    string          `json:"reason"`              // win, block, fork, forkBlock or evaluation
+	Cells     []CoordResponse `json:"cells,omitempty"`     // Winning cells of the threats behind the reason
+	Score     int             `json:"score"`               // Evaluation after the move from the Cross point of view
+	Solved    bool            `json:"solved"`              // Score is exact: the position was searched to the end
+	Variation []CoordResponse `json:"variation,omitempty"` // Expected continuation starting with the move
+}
+
+// PuzzleResponse is the JSON-serializable daily puzzle: X is to move and has a forced win
+type PuzzleResponse struct {
+	Date      string  `json:"date"`      // Day of the puzzle as YYYY-MM-DD
+	Grid      [][]int `json:"grid"`      // Position to solve: 0 empty, 1 X, 2 O, 3 blocked
+	WinLength int     `json:"winLength"` // Number of same marks in a row needed to win
+	Moves     int     `json:"moves"`     // Number of X moves of the forced win, the winning move included
+}
+
+// PuzzleSolutionRequest represents a solution line of the daily puzzle
+type PuzzleSolutionRequest struct {
+	Date  string          `json:"date"`  // Day of the puzzle as YYYY-MM-DD, today if empty
+	Moves []CoordResponse `json:"moves"` // Moves of X in order, the replies of O are made by the server
+}
+
+// PuzzleCheckResponse is the JSON-serializable result of a solution line of the daily puzzle
+type PuzzleCheckResponse struct {
+	Solved bool            `json:"solved"`
+	Line   []CoordResponse `json:"line"`             // Moves played: the submitted ones with the replies of O between them
+	Reason string          `json:"reason,omitempty"` // Why the line doesn't solve the puzzle
+}
+
+// EngineResponse is the JSON-serializable description of a computer engine
+type EngineResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Standard    bool     `json:"standard"` // Engine can play the standard 3x3 board
+	Larger      bool     `json:"larger"`   // Engine can play boards larger than 3x3
+	Variants    []string `json:"variants"` // Variants the engine can play
+}
+
+// ArenaRequest represents the settings of a match between two computer engines.
+// Zero board values select the standard 3x3 board
+type ArenaRequest struct {
+	First     string `json:"first"`     // Name of the first engine
+	Second    string `json:"second"`    // Name of the second engine
+	Games     int    `json:"games"`     // Number of games, the engines alternate colours
+	Size      int    `json:"size"`      // Number of rows and cols
+	WinLength int    `json:"winLength"` // Number of same marks in a row needed to win
+	Variant   string `json:"variant"`   // Rule set: classic, ultimate, gomoku, notakto, morris or qubic, classic if empty
+	Boards    int    `json:"boards"`    // Notakto only: number of boards, 3 by default
+	MoveTime  int    `json:"moveTime"`  // Time budget of a move in milliseconds, 0 for the configured one
+}
+
+// ArenaResponse is the JSON-serializable result of a match from the first engine point of view
+type ArenaResponse struct {
+	First          string  `json:"first"`
+	Second         string  `json:"second"`
+	Games          int     `json:"games"`
+	Wins           int     `json:"wins"`
+	Draws          int     `json:"draws"`
+	Losses         int     `json:"losses"`
+	AverageLength  float64 `json:"averageLength"`  // Average number of moves in a game
+	FirstMoveTime  float64 `json:"firstMoveTime"`  // Average move time of the first engine in milliseconds
+	SecondMoveTime float64 `json:"secondMoveTime"` // Average move time of the second engine in milliseconds
+	Duration       float64 `json:"duration"`       // Total time of the match in milliseconds
 }
@@ -5,12 +5,19 @@ import (
 )
 
 // NewRouter sets up the HTTP routes for the Tic Tac Toe game API using Gin.
-// It registers endpoints for retrieving games, making moves, and saving game data.
+// It registers endpoints for creating and retrieving games, making moves, listing engines, playing engine matches,
+// solving daily puzzles and saving game data.
 func NewRouter(h *GameHandler) *gin.Engine {
 	router := gin.Default()
 	router.GET("/tictactoe/games", h.GetAllGames)
+	router.POST("/tictactoe/games", h.CreateGame)
 	router.GET("/tictactoe/games/:id", h.GetGameByID)
 	router.POST("/tictactoe/games/save", h.SaveAllGames)
+	router.GET("/tictactoe/engines", h.GetEngines)
+	router.GET("/tictactoe/engines/:name/curve", h.GetLearningCurve)
+	router.POST("/tictactoe/arena", h.PlayArena)
+	router.GET("/tictactoe/puzzles/daily", h.GetDailyPuzzle)
+	router.POST("/tictactoe/puzzles/daily/check", h.CheckDailyPuzzle)
 	router.POST("/tictactoe/games/:id/move", h.ProcessMove)
 	router.POST("/tictactoe/games/move", h.ProcessMove)
 